@@ -0,0 +1,2 @@
+# Assistants can be imported by their Vapi ID.
+terraform import vapi_assistant.example_assistant 00000000-0000-0000-0000-000000000000
@@ -85,17 +85,44 @@ resource "vapi_assistant" "example_assistant" {
   analysis_plan = {
     summary_prompt         = "Summary prompt"
     structured_data_prompt = "Structured data prompt"
-    structured_data_schema = {
-      type = "object"
+    structured_data_schema_json = jsonencode({
+      type     = "object"
+      required = ["isCallNotAnswered"]
       properties = {
         isCallNotAnswered = {
-          description = "Indicates whether the call was not answered by the customer.",
-          type        = "string"
+          description = "Indicates whether the call was not answered by the customer."
+          type        = "boolean"
+        }
+        requestedServices = {
+          type = "array"
+          items = {
+            type = "object"
+            properties = {
+              name    = { type = "string" }
+              urgency = { type = "string", enum = ["low", "normal", "high"] }
+            }
+          }
         }
       }
-    }
+    })
     success_evaluation_prompt = "Success evaluation prompt"
     success_evaluation_rubric = "Checklist"
+    summary_plan = {
+      timeout_seconds = 10
+      messages = [
+        { role = "system", content = "Summarise the call in two sentences." },
+        { role = "user", content = "{{transcript}}" }
+      ]
+    }
+  }
+
+  artifact_plan = {
+    recording_format = "wav"
+    pcap_enabled     = true
+    transcript_plan = {
+      assistant_name = "Agent"
+      user_name      = "Caller"
+    }
   }
 
   message_plan = {
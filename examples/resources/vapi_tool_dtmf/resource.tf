@@ -0,0 +1,6 @@
+resource "vapi_tool_dtmf" "ivr" {
+  function = {
+    name        = "press_keys"
+    description = "Press keypad digits to navigate the phone menu."
+  }
+}
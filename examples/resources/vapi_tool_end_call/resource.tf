@@ -0,0 +1,13 @@
+resource "vapi_tool_end_call" "hang_up" {
+  function = {
+    name        = "end_call"
+    description = "End the call once the customer has no further questions."
+  }
+
+  messages = [
+    {
+      type    = "request-start"
+      content = "Thanks for calling, goodbye!"
+    }
+  ]
+}
@@ -4,8 +4,10 @@ resource "vapi_tool_function" "test-vapi_tool_function" {
   async       = false
   type        = "function"
 
-  server_url    = "https://somewhere.com/api/vapi/functions/basic"
-  server_secret = "123asd"
+  server = {
+    url    = "https://somewhere.com/api/vapi/functions/basic"
+    secret = "123asd"
+  }
 
   parameters = {
     type  = "object"
@@ -30,3 +32,39 @@ resource "vapi_tool_function" "test-vapi_tool_function" {
     }
   }
 }
+
+resource "vapi_tool_function" "crm_lookup" {
+  name        = "crm_lookup"
+  description = "Looks up a customer order in the CRM."
+  async       = false
+  type        = "function"
+
+  server = {
+    url = "https://somewhere.com/api/vapi/functions/crm"
+  }
+
+  parameters_json = jsonencode({
+    type     = "object"
+    required = ["items"]
+    properties = {
+      items = {
+        type = "array"
+        items = {
+          type     = "object"
+          required = ["sku"]
+          properties = {
+            sku      = { type = "string" }
+            quantity = { type = "integer", minimum = 1, default = 1 }
+          }
+        }
+      }
+      address = {
+        type = "object"
+        properties = {
+          city     = { type = "string" }
+          postcode = { type = "string", pattern = "^[0-9]{5}$" }
+        }
+      }
+    }
+  })
+}
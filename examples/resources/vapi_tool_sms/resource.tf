@@ -0,0 +1,12 @@
+resource "vapi_tool_sms" "confirmation" {
+  messages = [
+    {
+      type    = "request-complete"
+      content = "I've sent you a text with the details."
+    },
+    {
+      type    = "request-failed"
+      content = "Sorry, I couldn't send the text message."
+    }
+  ]
+}
@@ -0,0 +1,54 @@
+resource "vapi_tool_transfer_call" "support" {
+  destinations = [
+    {
+      type        = "number"
+      number      = "+15551234567"
+      caller_id   = "{{customer.number}}"
+      message     = "Connecting you to our support team."
+      description = "Transfer when the customer needs technical help."
+
+      transfer_plan = {
+        mode = "warm-transfer-say-summary"
+
+        summary_plan = {
+          messages = [
+            {
+              role    = "system"
+              content = "Summarise the call for the support agent in two sentences."
+            },
+            {
+              role    = "user"
+              content = "Here is the transcript:\n\n{{transcript}}"
+            }
+          ]
+        }
+      }
+    },
+    {
+      type    = "sip"
+      sip_uri = "sip:billing@pbx.example.com"
+      sip_headers = {
+        X-Team = "billing"
+      }
+      description = "Transfer billing questions."
+
+      transfer_plan = {
+        mode     = "blind-transfer"
+        sip_verb = "refer"
+      }
+    },
+    {
+      type           = "assistant"
+      assistant_name = "Sales"
+      transfer_mode  = "rolling-history"
+      description    = "Hand over to the sales assistant."
+    }
+  ]
+
+  messages = [
+    {
+      type    = "request-start"
+      content = "Please hold while I transfer your call."
+    }
+  ]
+}
@@ -0,0 +1,13 @@
+resource "vapi_tool_voicemail" "leave_message" {
+  function = {
+    name        = "leave_voicemail"
+    description = "Use when the call reaches a voicemail greeting instead of a person."
+  }
+
+  messages = [
+    {
+      type    = "request-start"
+      content = "Hi, this is Acme calling about your appointment. Please call us back."
+    }
+  ]
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+// schemaPathResolver is satisfied by the schema carried on plans and states
+// and is used to check whether an API field maps onto a resource attribute.
+type schemaPathResolver interface {
+	TypeAtPath(ctx context.Context, p path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addAPIError appends diagnostics describing err. Validation messages that
+// start with a request field (e.g. "voice.voiceId should not be empty") are
+// attached to the matching attribute when the schema has one; everything else
+// is reported as a single error with the given summary.
+func addAPIError(ctx context.Context, diags *diag.Diagnostics, s schemaPathResolver, summary string, err error) {
+	var apiErr *vapi.APIError
+	if !errors.As(err, &apiErr) || s == nil {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	unmapped := 0
+	for _, message := range apiErr.Messages {
+		attrPath, ok := attributePathFromMessage(ctx, s, message)
+		if !ok {
+			unmapped++
+			continue
+		}
+		detail := message
+		if apiErr.RequestID != "" {
+			detail += " (request ID " + apiErr.RequestID + ")"
+		}
+		diags.AddAttributeError(attrPath, summary, detail)
+	}
+
+	if unmapped > 0 || len(apiErr.Messages) == 0 {
+		diags.AddError(summary, apiErr.Error())
+	}
+}
+
+// attributePathFromMessage converts the leading field reference of an API
+// validation message, such as "transcriber.keywords.0", into a schema path.
+func attributePathFromMessage(ctx context.Context, s schemaPathResolver, message string) (path.Path, bool) {
+	field, _, _ := strings.Cut(strings.TrimSpace(message), " ")
+	if field == "" {
+		return path.Empty(), false
+	}
+
+	var attrPath path.Path
+	for i, segment := range strings.Split(field, ".") {
+		if index, err := strconv.Atoi(segment); err == nil && i > 0 {
+			attrPath = attrPath.AtListIndex(index)
+			continue
+		}
+		if !isFieldName(segment) {
+			return path.Empty(), false
+		}
+		if i == 0 {
+			attrPath = path.Root(snakeCase(segment))
+		} else {
+			attrPath = attrPath.AtName(snakeCase(segment))
+		}
+	}
+
+	if _, diags := s.TypeAtPath(ctx, attrPath); diags.HasError() {
+		return path.Empty(), false
+	}
+	return attrPath, true
+}
+
+func isFieldName(segment string) bool {
+	if segment == "" || !unicode.IsLetter(rune(segment[0])) {
+		return false
+	}
+	for _, r := range segment {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// snakeCase converts API field names such as "twilioAccountSid" or "sipURI"
+// to the attribute naming used by the schemas.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+func TestAttributePathFromMessage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&VAPISIPTrunkResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cases := []struct {
+		message string
+		want    path.Path
+		ok      bool
+	}{
+		{message: "name must be a string", want: path.Root("name"), ok: true},
+		{message: "gateways.0.ip must be an ip address", want: path.Root("gateways").AtListIndex(0).AtName("ip"), ok: true},
+		{message: "outboundAuthenticationPlan.sipRegisterPlan.realm should not be empty", want: path.Root("outbound_authentication_plan").AtName("sip_register_plan").AtName("realm"), ok: true},
+		{message: "property foo should not exist", ok: false},
+		{message: "unknownField must be a string", ok: false},
+		{message: "Couldn't find credential", ok: false},
+		{message: "", ok: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.message, func(t *testing.T) {
+			got, ok := attributePathFromMessage(ctx, schemaResp.Schema, tc.message)
+			if ok != tc.ok {
+				t.Fatalf("expected ok=%v, got %v (%s)", tc.ok, ok, got)
+			}
+			if ok && !got.Equal(tc.want) {
+				t.Fatalf("expected path %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"name":             "name",
+		"voiceId":          "voice_id",
+		"twilioAccountSid": "twilio_account_sid",
+		"sipURI":           "sip_uri",
+		"numberE164Check":  "number_e164_check",
+	}
+	for in, want := range cases {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAddAPIErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&VAPISIPTrunkResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	apiErr := &vapi.APIError{
+		StatusCode: http.StatusBadRequest,
+		Method:     http.MethodPost,
+		Endpoint:   "credential",
+		RequestID:  "req-1",
+		Messages:   []string{"name must be a string", "something else went wrong"},
+	}
+
+	var diags diag.Diagnostics
+	addAPIError(ctx, &diags, schemaResp.Schema, "Unable to create SIP trunk", apiErr)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected attribute and general error, got %v", diags)
+	}
+
+	attrDiag, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !attrDiag.Path().Equal(path.Root("name")) {
+		t.Fatalf("expected diagnostic on name, got %#v", diags[0])
+	}
+	if attrDiag.Summary() != "Unable to create SIP trunk" || attrDiag.Detail() != "name must be a string (request ID req-1)" {
+		t.Fatalf("unexpected attribute diagnostic: %s / %s", attrDiag.Summary(), attrDiag.Detail())
+	}
+	if !strings.Contains(diags[1].Detail(), "something else went wrong") {
+		t.Fatalf("expected general diagnostic to carry the API error, got %s", diags[1].Detail())
+	}
+
+	var plain diag.Diagnostics
+	addAPIError(ctx, &plain, schemaResp.Schema, "Unable to read SIP trunk", errors.New("request failed: connection refused"))
+	if plain.ErrorsCount() != 1 || plain[0].Detail() != "request failed: connection refused" {
+		t.Fatalf("unexpected diagnostics for transport error: %v", plain)
+	}
+}
+
+func TestSIPTrunkReadRemovesMissingResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	transport := &queueRoundTripper{
+		t: t,
+		responses: []queuedResponse{
+			{method: http.MethodGet, path: "/credential/trunk-1", status: http.StatusNotFound, body: []byte(`{"message":"Not Found","statusCode":404}`)},
+		},
+	}
+
+	res := &VAPISIPTrunkResource{
+		client: &vapi.APIClient{
+			BaseURL:    "https://api.example.com",
+			Token:      "token",
+			HTTPClient: &http.Client{Transport: transport},
+		},
+	}
+
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &VAPISIPTrunkResourceModel{
+		ID:                         types.StringValue("trunk-1"),
+		SIPProvider:                types.StringValue("byo-sip-trunk"),
+		Name:                       types.StringValue("trunk"),
+		Gateways:                   []SIPGatewayModel{{IP: types.StringValue("10.0.0.1")}},
+		OutboundLeadingPlusEnabled: types.BoolValue(false),
+		TechPrefix:                 types.StringNull(),
+		SIPDiversionHeader:         types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("state diagnostics: %v", diags)
+	}
+
+	readResp := resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatalf("expected resource to be removed from state")
+	}
+	transport.assertDrained()
+}
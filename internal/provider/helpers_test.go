@@ -127,14 +127,15 @@ func TestMapVAPIAssistantRequestAndResponse(t *testing.T) {
 		},
 	}
 
-	request := mapVAPIAssistantRequest(&model)
-	if request.Name != "assistant" || request.Model == nil || request.Model.KnowledgeBase == nil {
+	request := mapVAPIAssistantRequest(&model, nil)
+	requestModel, _ := request.Model.Get()
+	if request.Name != "assistant" || requestModel == nil || requestModel.KnowledgeBase == nil {
 		t.Fatalf("unexpected request mapping: %#v", request)
 	}
-	if request.ArtifactPlan == nil || request.ArtifactPlan.RecordingFormat != "mp3" {
+	if artifactPlan, ok := request.ArtifactPlan.Get(); !ok || artifactPlan.RecordingFormat != "mp3" {
 		t.Fatalf("expected default recording format, got %#v", request.ArtifactPlan)
 	}
-	if request.Server == nil || request.Server.URL != "https://hook.example.com" {
+	if server, ok := request.Server.Get(); !ok || server.URL != "https://hook.example.com" {
 		t.Fatalf("expected server mapping, got %#v", request.Server)
 	}
 
@@ -144,83 +145,94 @@ func TestMapVAPIAssistantRequestAndResponse(t *testing.T) {
 	numWords := int64(4)
 	live := true
 
+	voice, _ := request.Voice.Get()
+	transcriber, _ := request.Transcriber.Get()
+	messagePlan, _ := request.MessagePlan.Get()
+	startSpeakingPlan, _ := request.StartSpeakingPlan.Get()
+	stopSpeakingPlan, _ := request.StopSpeakingPlan.Get()
+	clientMessages, _ := request.ClientMessages.Get()
+	serverMessages, _ := request.ServerMessages.Get()
+	keywords, _ := request.Keywords.Get()
+	endCallPhrases, _ := request.EndCallPhrases.Get()
+	analysisPlan, _ := request.AnalysisPlan.Get()
+	structuredDataSchema, _ := analysisPlan.StructuredDataSchema.Get()
 	resp := &vapi.Assistant{
 		ID:                           "assistant-1",
 		OrgID:                        "org-1",
 		Name:                         request.Name,
-		FirstMessageMode:             request.FirstMessageMode,
-		HipaaEnabled:                 request.HipaaEnabled,
-		ClientMessages:               request.ClientMessages,
-		ServerMessages:               request.ServerMessages,
-		BackgroundSound:              request.BackgroundSound,
-		BackgroundDenoising:          request.BackgroundDenoising,
-		ModelOutputEnabled:           request.ModelOutputEnabled,
-		Language:                     request.Language,
-		ForwardingPhoneNumber:        request.ForwardingPhoneNumber,
-		InterruptionsEnabled:         request.InterruptionsEnabled,
-		EndCallFunctionEnabled:       request.EndCallFunctionEnabled,
-		DialKeypadFunctionEnabled:    request.DialKeypadFunctionEnabled,
-		FillersEnabled:               request.FillersEnabled,
+		FirstMessageMode:             nullablePointer(request.FirstMessageMode),
+		HipaaEnabled:                 nullablePointer(request.HipaaEnabled),
+		ClientMessages:               clientMessages,
+		ServerMessages:               serverMessages,
+		BackgroundSound:              nullablePointer(request.BackgroundSound),
+		BackgroundDenoising:          nullablePointer(request.BackgroundDenoising),
+		ModelOutputEnabled:           nullablePointer(request.ModelOutputEnabled),
+		Language:                     nullablePointer(request.Language),
+		ForwardingPhoneNumber:        nullablePointer(request.ForwardingPhoneNumber),
+		InterruptionsEnabled:         nullablePointer(request.InterruptionsEnabled),
+		EndCallFunctionEnabled:       nullablePointer(request.EndCallFunctionEnabled),
+		DialKeypadFunctionEnabled:    nullablePointer(request.DialKeypadFunctionEnabled),
+		FillersEnabled:               nullablePointer(request.FillersEnabled),
 		SilenceTimeoutSeconds:        &silence,
 		ResponseDelaySeconds:         &respDelay,
 		NumWordsToInterruptAssistant: &numWords,
 		LiveTranscriptsEnabled:       &live,
-		Keywords:                     request.Keywords,
+		Keywords:                     keywords,
 		ParentID:                     &parent,
 		Voice: &vapi.Voice{
-			Model:           request.Voice.Model,
-			VoiceID:         request.Voice.VoiceID,
-			Provider:        request.Voice.Provider,
-			Stability:       request.Voice.Stability,
-			SimilarityBoost: request.Voice.SimilarityBoost,
+			Model:           voice.Model,
+			VoiceID:         voice.VoiceID,
+			Provider:        voice.Provider,
+			Stability:       voice.Stability,
+			SimilarityBoost: voice.SimilarityBoost,
 		},
 		Model: &vapi.Model{
-			Model:        request.Model.Model,
-			SystemPrompt: request.Model.SystemPrompt,
-			Provider:     request.Model.Provider,
-			Temperature:  request.Model.Temperature,
-			MaxTokens:    request.Model.MaxTokens,
-			ToolIDs:      request.Model.ToolIDs,
+			Model:        requestModel.Model,
+			SystemPrompt: requestModel.SystemPrompt,
+			Provider:     requestModel.Provider,
+			Temperature:  requestModel.Temperature,
+			MaxTokens:    requestModel.MaxTokens,
+			ToolIDs:      requestModel.ToolIDs,
 			KnowledgeBase: &vapi.KnowledgeBase{
-				TopK:     request.Model.KnowledgeBase.TopK,
-				FileIDs:  request.Model.KnowledgeBase.FileIDs,
-				Provider: request.Model.KnowledgeBase.Provider,
+				TopK:     requestModel.KnowledgeBase.TopK,
+				FileIDs:  requestModel.KnowledgeBase.FileIDs,
+				Provider: requestModel.KnowledgeBase.Provider,
 			},
 		},
-		RecordingEnabled: request.RecordingEnabled,
-		FirstMessage:     request.FirstMessage,
-		VoicemailMessage: request.VoicemailMessage,
-		EndCallMessage:   request.EndCallMessage,
+		RecordingEnabled: nullablePointer(request.RecordingEnabled),
+		FirstMessage:     nullablePointer(request.FirstMessage),
+		VoicemailMessage: nullablePointer(request.VoicemailMessage),
+		EndCallMessage:   nullablePointer(request.EndCallMessage),
 		Transcriber: &vapi.Transcriber{
-			Provider: request.Transcriber.Provider,
-			Model:    request.Transcriber.Model,
-			Language: request.Transcriber.Language,
+			Provider: transcriber.Provider,
+			Model:    transcriber.Model,
+			Language: transcriber.Language,
 		},
-		EndCallPhrases:     request.EndCallPhrases,
-		MaxDurationSeconds: request.MaxDurationSeconds,
+		EndCallPhrases:     endCallPhrases,
+		MaxDurationSeconds: nullablePointer(request.MaxDurationSeconds),
 		AnalysisPlan: &vapi.AnalysisPlan{
-			SummaryPrompt:           request.AnalysisPlan.SummaryPrompt,
-			StructuredDataPrompt:    request.AnalysisPlan.StructuredDataPrompt,
-			StructuredDataSchema:    request.AnalysisPlan.StructuredDataSchema,
-			SuccessEvaluationPrompt: request.AnalysisPlan.SuccessEvaluationPrompt,
-			SuccessEvaluationRubric: request.AnalysisPlan.SuccessEvaluationRubric,
+			SummaryPrompt:           nullablePointer(analysisPlan.SummaryPrompt),
+			StructuredDataPrompt:    nullablePointer(analysisPlan.StructuredDataPrompt),
+			StructuredDataSchema:    structuredDataSchema,
+			SuccessEvaluationPrompt: nullablePointer(analysisPlan.SuccessEvaluationPrompt),
+			SuccessEvaluationRubric: nullablePointer(analysisPlan.SuccessEvaluationRubric),
 		},
 		MessagePlan: &vapi.MessagePlan{
-			IdleMessages: request.MessagePlan.IdleMessages,
+			IdleMessages: messagePlan.IdleMessages,
 		},
 		StartSpeakingPlan: &vapi.StartSpeakingPlan{
-			WaitSeconds:             request.StartSpeakingPlan.WaitSeconds,
-			SmartEndpointingEnabled: request.StartSpeakingPlan.SmartEndpointingEnabled,
+			WaitSeconds:             startSpeakingPlan.WaitSeconds,
+			SmartEndpointingEnabled: startSpeakingPlan.SmartEndpointingEnabled,
 			TranscriptionEndpointingPlan: &vapi.TranscriptionEndpointingPlan{
-				OnPunctuationSeconds:   request.StartSpeakingPlan.TranscriptionEndpointingPlan.OnPunctuationSeconds,
-				OnNoPunctuationSeconds: request.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds,
-				OnNumberSeconds:        request.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNumberSeconds,
+				OnPunctuationSeconds:   startSpeakingPlan.TranscriptionEndpointingPlan.OnPunctuationSeconds,
+				OnNoPunctuationSeconds: startSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds,
+				OnNumberSeconds:        startSpeakingPlan.TranscriptionEndpointingPlan.OnNumberSeconds,
 			},
 		},
 		StopSpeakingPlan: &vapi.StopSpeakingPlan{
-			NumWords:       request.StopSpeakingPlan.NumWords,
-			VoiceSeconds:   request.StopSpeakingPlan.VoiceSeconds,
-			BackoffSeconds: request.StopSpeakingPlan.BackoffSeconds,
+			NumWords:       stopSpeakingPlan.NumWords,
+			VoiceSeconds:   stopSpeakingPlan.VoiceSeconds,
+			BackoffSeconds: stopSpeakingPlan.BackoffSeconds,
 		},
 		ArtifactPlan: &vapi.ArtifactPlan{
 			RecordingFormat: "mp3",
@@ -239,11 +251,18 @@ func TestMapVAPIAssistantRequestAndResponse(t *testing.T) {
 	if mapped.StartSpeakingPlan == nil || mapped.StopSpeakingPlan == nil {
 		t.Fatalf("expected speaking plans mapped: %#v", mapped)
 	}
-	if mapped.AnalysisPlan == nil || mapped.AnalysisPlan.StructuredDataSchema == nil {
+	if mapped.AnalysisPlan == nil || mapped.AnalysisPlan.StructuredDataSchemaJSON.IsNull() {
 		t.Fatalf("expected analysis plan mapping: %#v", mapped.AnalysisPlan)
 	}
 }
 
+func nullablePointer[T any](n vapi.Nullable[T]) *T {
+	if v, ok := n.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
 func TestMapVAPIAssistantRequestNilBranches(t *testing.T) {
 	minimal := VAPIAssistantResourceModel{
 		Name:             types.StringValue("assistant"),
@@ -255,8 +274,8 @@ func TestMapVAPIAssistantRequestNilBranches(t *testing.T) {
 		Keywords:         ListValueFromStrings(nil),
 	}
 
-	req := mapVAPIAssistantRequest(&minimal)
-	if req.Model != nil || req.Voice != nil || req.MessagePlan != nil {
+	req := mapVAPIAssistantRequest(&minimal, nil)
+	if !req.Model.IsZero() || !req.Voice.IsZero() || !req.MessagePlan.IsZero() {
 		t.Fatalf("expected optional blocks to be nil")
 	}
 
@@ -332,31 +351,31 @@ func TestBindVAPIToolFunctionResourceData(t *testing.T) {
 		UpdatedAt: "later",
 		Type:      "function",
 		Async:     true,
-		Server: vapi.ResponseServer{
+		Server: &vapi.Server{
 			URL: "https://server.example.com",
 		},
 		Function: vapi.ResponseFunction{
 			Name:        "name",
 			Description: "desc",
 			Async:       true,
-			Parameters: vapi.ResponseFunctionParams{
+			Parameters: mustMarshal(t, vapi.FunctionParams{
 				Type:     "object",
 				Required: []string{"foo", "bar"},
-				Properties: map[string]vapi.ResponseProperty{
+				Properties: map[string]vapi.Property{
 					"foo": {
 						Type:        "string",
 						Description: "a",
 						Enum:        []string{"x", "y"},
 					},
 				},
-			},
+			}),
 		},
 	}
 
-	var model VAPIToolFunctionResourceModel
+	model := VAPIToolFunctionResourceModel{Parameters: &Parameters{}}
 	bindVAPIToolFunctionResourceData(&model, resp)
 
-	if model.ID.ValueString() != "tool-1" || model.ServerURL.ValueString() != "https://server.example.com" {
+	if model.ID.ValueString() != "tool-1" || model.Server == nil || model.Server.URL.ValueString() != "https://server.example.com" {
 		t.Fatalf("unexpected ID or server mapping: %#v", model)
 	}
 
@@ -375,6 +394,8 @@ type queuedResponse struct {
 	path   string
 	status int
 	body   []byte
+	// wantBody, when set, is compared with the request body.
+	wantBody string
 }
 
 type queueRoundTripper struct {
@@ -393,6 +414,12 @@ func (rt *queueRoundTripper) RoundTrip(req *http.Request) (*http.Response, error
 	if req.Method != next.method || req.URL.Path != next.path {
 		rt.t.Fatalf("unexpected request, got %s %s want %s %s", req.Method, req.URL.Path, next.method, next.path)
 	}
+	if next.wantBody != "" {
+		body, _ := io.ReadAll(req.Body)
+		if body = bytes.TrimSpace(body); string(body) != next.wantBody {
+			rt.t.Fatalf("unexpected %s %s body, got %s want %s", req.Method, req.URL.Path, body, next.wantBody)
+		}
+	}
 
 	return &http.Response{
 		StatusCode: next.status,
@@ -2,14 +2,20 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
-	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure VAPIProvider satisfies various provider interfaces.
@@ -26,10 +32,33 @@ type VAPIProvider struct {
 
 // ScaffoldingProviderModel describes the provider data model.
 type ScaffoldingProviderModel struct {
-	URL   string `tfsdk:"url"`
-	Token string `tfsdk:"token"`
+	URL          types.String `tfsdk:"url"`
+	Token        types.String `tfsdk:"token"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait types.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	VerifyCredentials types.Bool `tfsdk:"verify_credentials"`
+
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ExtraHeaders       types.Map    `tfsdk:"extra_headers"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
 }
 
+// Environment variables consulted when the matching provider attribute is
+// not set. Token variables are checked in order.
+var (
+	tokenEnvVars   = []string{"VAPI_API_KEY", "VAPI_TOKEN"}
+	baseURLEnvVars = []string{"VAPI_BASE_URL"}
+)
+
 func (p *VAPIProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "vapi"
 	resp.Version = p.version
@@ -39,14 +68,63 @@ func (p *VAPIProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				MarkdownDescription: "The base URL of the remote API.",
-				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The base URL of the remote API. May also be set with the `VAPI_BASE_URL` environment variable. Defaults to `%s`.", vapi.DefaultBaseURL),
+				Optional:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The Bearer token used for API authentication.",
-				Required:            true,
+				MarkdownDescription: "The Bearer token used for API authentication. May also be set with the `VAPI_API_KEY` or `VAPI_TOKEN` environment variable.",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries for rate-limited or transient API failures. Defaults to `%d`; `0` disables retries.", vapi.DefaultMaxRetries),
+				Optional:            true,
+			},
+			"retry_min_wait": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Minimum backoff between retries as a Go duration (e.g. `500ms`). Defaults to `%s`.", vapi.DefaultRetryMinWait),
+				Optional:            true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum backoff between retries as a Go duration (e.g. `30s`). Also caps `Retry-After`. Defaults to `%s`.", vapi.DefaultRetryMaxWait),
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum sustained rate of API requests shared by all resources. Unset or `0` disables rate limiting.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of in-flight API requests shared by all resources. Unset or `0` disables the cap.",
+				Optional:            true,
+			},
+			"verify_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Make a lightweight API call while configuring the provider so an invalid token fails before any resource is planned. Defaults to `false`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Timeout for a single HTTP attempt as a Go duration (e.g. `30s`). Retries get a fresh timeout. Defaults to `%s`; `0s` disables the timeout.", vapi.DefaultRequestTimeout),
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP(S) proxy used for all API requests. When unset, the standard `HTTPS_PROXY`/`NO_PROXY` environment variables apply.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates trusted in addition to the system roots, e.g. for a TLS-intercepting egress proxy.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable TLS certificate verification. Only intended for debugging. Defaults to `false`.",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header, e.g. to identify internal tooling in Vapi support requests.",
+				Optional:            true,
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Additional HTTP headers sent with every API request. Headers set by the provider, such as `Authorization`, are not overridden.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -60,10 +138,107 @@ func (p *VAPIProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	if data.URL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("url"), "Unknown Vapi API URL", "The provider cannot create the Vapi API client because url is unknown. Set it statically or use the VAPI_BASE_URL environment variable.")
+	}
+	if data.Token.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Unknown Vapi API Token", "The provider cannot create the Vapi API client because token is unknown. Set it statically or use the VAPI_API_KEY environment variable.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	baseURL := stringOrEnv(data.URL, baseURLEnvVars)
+	if baseURL == "" {
+		baseURL = vapi.DefaultBaseURL
+	}
+	token := stringOrEnv(data.Token, tokenEnvVars)
+	if token == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Missing Vapi API Token",
+			"The provider cannot create the Vapi API client because no token was configured. Set the token attribute or the VAPI_API_KEY (or VAPI_TOKEN) environment variable.",
+		)
+		return
+	}
+
+	transportOpts := vapi.TransportOptions{
+		Timeout:            vapi.DefaultRequestTimeout,
+		ProxyURL:           data.ProxyURL.ValueString(),
+		CACertPEM:          data.CACertPEM.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+	if timeout, ok := parseProviderDuration(data.RequestTimeout, path.Root("request_timeout"), resp); ok {
+		transportOpts.Timeout = timeout
+	}
+	if !data.ExtraHeaders.IsNull() && !data.ExtraHeaders.IsUnknown() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &transportOpts.Headers, false)...)
+	}
+
+	httpClient, err := vapi.NewHTTPClient(transportOpts)
+	switch {
+	case errors.Is(err, vapi.ErrInvalidProxyURL):
+		resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid Transport Configuration", err.Error())
+	case errors.Is(err, vapi.ErrInvalidCACert):
+		resp.Diagnostics.AddAttributeError(path.Root("ca_cert_pem"), "Invalid Transport Configuration", err.Error())
+	case err != nil:
+		resp.Diagnostics.AddError("Invalid Transport Configuration", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	client := &vapi.APIClient{
-		BaseURL:    data.URL,
-		Token:      data.Token,
-		HTTPClient: &http.Client{},
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		Token:        token,
+		HTTPClient:   httpClient,
+		UserAgent:    p.userAgent(req.TerraformVersion, data.UserAgentSuffix.ValueString()),
+		MaxRetries:   vapi.DefaultMaxRetries,
+		RetryMinWait: vapi.DefaultRetryMinWait,
+		RetryMaxWait: vapi.DefaultRetryMaxWait,
+	}
+
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid Retry Configuration", "max_retries must not be negative.")
+		}
+		client.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if wait, ok := parseProviderDuration(data.RetryMinWait, path.Root("retry_min_wait"), resp); ok {
+		client.RetryMinWait = wait
+	}
+	if wait, ok := parseProviderDuration(data.RetryMaxWait, path.Root("retry_max_wait"), resp); ok {
+		client.RetryMaxWait = wait
+	}
+	if !data.RequestsPerSecond.IsNull() {
+		if data.RequestsPerSecond.ValueFloat64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid Throttling Configuration", "requests_per_second must not be negative.")
+		}
+		client.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+	if !data.MaxConcurrentRequests.IsNull() {
+		if data.MaxConcurrentRequests.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid Throttling Configuration", "max_concurrent_requests must not be negative.")
+		}
+		client.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+	if client.RetryMaxWait < client.RetryMinWait {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_max_wait"), "Invalid Retry Configuration", "retry_max_wait must not be shorter than retry_min_wait.")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.VerifyCredentials.ValueBool() {
+		if err := client.VerifyCredentials(ctx); err != nil {
+			if vapi.IsUnauthorized(err) {
+				resp.Diagnostics.AddAttributeError(path.Root("token"), "Invalid Vapi API Token", fmt.Sprintf("The Vapi API rejected the configured token: %s", err))
+			} else {
+				resp.Diagnostics.AddError("Unable to Verify Vapi Credentials", err.Error())
+			}
+			return
+		}
 	}
 
 	resp.DataSourceData = client
@@ -77,6 +252,11 @@ func (p *VAPIProvider) Resources(ctx context.Context) []func() resource.Resource
 		NewVAPIPhoneNumberResource,
 		NewVAPIToolFunctionResource,
 		NewVAPIToolQueryFunctionResource,
+		NewVAPIToolTransferCallResource,
+		NewVAPIToolEndCallResource,
+		NewVAPIToolDTMFResource,
+		NewVAPIToolSMSResource,
+		NewVAPIToolVoicemailResource,
 		NewVAPISIPTrunkResource,
 		NewVAPISIPTrunkPhoneNumberResource,
 	}
@@ -97,3 +277,49 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// userAgent identifies the provider and Terraform versions to the Vapi API.
+func (p *VAPIProvider) userAgent(terraformVersion, suffix string) string {
+	version := p.version
+	if version == "" {
+		version = "dev"
+	}
+	if terraformVersion == "" {
+		terraformVersion = "unknown"
+	}
+
+	ua := fmt.Sprintf("terraform-provider-vapi/%s terraform/%s", version, terraformVersion)
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
+// stringOrEnv returns the configured value, falling back to the first
+// non-empty environment variable in names.
+func stringOrEnv(value types.String, names []string) string {
+	if !value.IsNull() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseProviderDuration parses an optional duration attribute, reporting an
+// attribute error when the value is malformed or negative.
+func parseProviderDuration(value types.String, attrPath path.Path, resp *provider.ConfigureResponse) (time.Duration, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		resp.Diagnostics.AddAttributeError(attrPath, "Invalid Duration", fmt.Sprintf("Expected a non-negative Go duration such as \"1s\", got %q.", value.ValueString()))
+		return 0, false
+	}
+	return d, true
+}
@@ -2,9 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	frameworkProvider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -34,14 +40,20 @@ func TestProviderMetadataSchemaAndConfigure(t *testing.T) {
 
 	var schemaResp frameworkProvider.SchemaResponse
 	prov.Schema(ctx, frameworkProvider.SchemaRequest{}, &schemaResp)
-	if len(schemaResp.Schema.Attributes) != 2 {
-		t.Fatalf("expected provider schema to define 2 attributes")
+	if len(schemaResp.Schema.Attributes) != 14 {
+		t.Fatalf("expected provider schema to define 14 attributes")
 	}
 
-	configValue := buildProviderConfig(t, ctx)
+	configValue := buildProviderConfig(t, ctx, map[string]attr.Value{
+		"max_retries":             types.Int64Value(2),
+		"retry_min_wait":          types.StringValue("250ms"),
+		"requests_per_second":     types.Float64Value(10),
+		"max_concurrent_requests": types.Int64Value(4),
+	})
 
 	confResp := &frameworkProvider.ConfigureResponse{}
 	prov.Configure(ctx, frameworkProvider.ConfigureRequest{
+		TerraformVersion: "1.9.5",
 		Config: tfsdk.Config{
 			Raw:    configValue,
 			Schema: schemaResp.Schema,
@@ -52,9 +64,19 @@ func TestProviderMetadataSchemaAndConfigure(t *testing.T) {
 		t.Fatalf("unexpected configure diagnostics: %v", confResp.Diagnostics)
 	}
 
-	if _, ok := confResp.ResourceData.(*vapi.APIClient); !ok {
+	client, ok := confResp.ResourceData.(*vapi.APIClient)
+	if !ok {
 		t.Fatalf("expected resource data to be *vapi.APIClient")
 	}
+	if client.MaxRetries != 2 || client.RetryMinWait != 250*time.Millisecond || client.RetryMaxWait != vapi.DefaultRetryMaxWait {
+		t.Fatalf("unexpected retry settings: %d %s %s", client.MaxRetries, client.RetryMinWait, client.RetryMaxWait)
+	}
+	if client.UserAgent != "terraform-provider-vapi/1.2.3 terraform/1.9.5" {
+		t.Fatalf("unexpected user agent %q", client.UserAgent)
+	}
+	if client.RequestsPerSecond != 10 || client.MaxConcurrentRequests != 4 {
+		t.Fatalf("unexpected throttling settings: %v %d", client.RequestsPerSecond, client.MaxConcurrentRequests)
+	}
 	if _, ok := confResp.DataSourceData.(*vapi.APIClient); !ok {
 		t.Fatalf("expected data source data to be *vapi.APIClient")
 	}
@@ -70,14 +92,280 @@ func TestProviderMetadataSchemaAndConfigure(t *testing.T) {
 	}
 }
 
-func buildProviderConfig(t *testing.T, ctx context.Context) tftypes.Value {
-	obj, diags := types.ObjectValue(map[string]attr.Type{
-		"url":   types.StringType,
-		"token": types.StringType,
-	}, map[string]attr.Value{
-		"url":   types.StringValue("https://api.example.com"),
-		"token": types.StringValue("secret"),
+func TestProviderConfigureRejectsInvalidRetrySettings(t *testing.T) {
+	ctx := context.Background()
+	prov := &VAPIProvider{version: "test"}
+
+	var schemaResp frameworkProvider.SchemaResponse
+	prov.Schema(ctx, frameworkProvider.SchemaRequest{}, &schemaResp)
+
+	cases := map[string]map[string]attr.Value{
+		"malformed duration": {"retry_min_wait": types.StringValue("soon")},
+		"negative retries":   {"max_retries": types.Int64Value(-1)},
+		"max below min":      {"retry_min_wait": types.StringValue("10s"), "retry_max_wait": types.StringValue("1s")},
+		"negative rate":      {"requests_per_second": types.Float64Value(-1)},
+		"negative cap":       {"max_concurrent_requests": types.Int64Value(-2)},
+	}
+
+	for name, overrides := range cases {
+		t.Run(name, func(t *testing.T) {
+			confResp := &frameworkProvider.ConfigureResponse{}
+			prov.Configure(ctx, frameworkProvider.ConfigureRequest{
+				Config: tfsdk.Config{
+					Raw:    buildProviderConfig(t, ctx, overrides),
+					Schema: schemaResp.Schema,
+				},
+			}, confResp)
+
+			if !confResp.Diagnostics.HasError() {
+				t.Fatalf("expected configure diagnostics")
+			}
+			if confResp.ResourceData != nil {
+				t.Fatalf("expected no client to be configured")
+			}
+		})
+	}
+}
+
+func configureProvider(t *testing.T, ctx context.Context, overrides map[string]attr.Value) *frameworkProvider.ConfigureResponse {
+	t.Helper()
+
+	prov := &VAPIProvider{version: "test"}
+	var schemaResp frameworkProvider.SchemaResponse
+	prov.Schema(ctx, frameworkProvider.SchemaRequest{}, &schemaResp)
+
+	confResp := &frameworkProvider.ConfigureResponse{}
+	prov.Configure(ctx, frameworkProvider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Raw:    buildProviderConfig(t, ctx, overrides),
+			Schema: schemaResp.Schema,
+		},
+	}, confResp)
+	return confResp
+}
+
+func TestProviderConfigureEnvironmentFallbacks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults url and reads VAPI_API_KEY", func(t *testing.T) {
+		t.Setenv("VAPI_API_KEY", "env-key")
+		t.Setenv("VAPI_TOKEN", "legacy-key")
+		t.Setenv("VAPI_BASE_URL", "")
+
+		confResp := configureProvider(t, ctx, map[string]attr.Value{
+			"url":   types.StringNull(),
+			"token": types.StringNull(),
+		})
+		if confResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", confResp.Diagnostics)
+		}
+		client := configuredClient(t, confResp)
+		if client.BaseURL != vapi.DefaultBaseURL || client.Token != "env-key" {
+			t.Fatalf("unexpected client settings: %s %s", client.BaseURL, client.Token)
+		}
+	})
+
+	t.Run("falls back to VAPI_TOKEN and VAPI_BASE_URL", func(t *testing.T) {
+		t.Setenv("VAPI_API_KEY", "")
+		t.Setenv("VAPI_TOKEN", "legacy-key")
+		t.Setenv("VAPI_BASE_URL", "https://eu.example.com/")
+
+		confResp := configureProvider(t, ctx, map[string]attr.Value{
+			"url":   types.StringNull(),
+			"token": types.StringNull(),
+		})
+		if confResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", confResp.Diagnostics)
+		}
+		client := configuredClient(t, confResp)
+		if client.BaseURL != "https://eu.example.com" || client.Token != "legacy-key" {
+			t.Fatalf("unexpected client settings: %s %s", client.BaseURL, client.Token)
+		}
+	})
+
+	t.Run("config wins over environment", func(t *testing.T) {
+		t.Setenv("VAPI_API_KEY", "env-key")
+		t.Setenv("VAPI_BASE_URL", "https://env.example.com")
+
+		confResp := configureProvider(t, ctx, nil)
+		client := configuredClient(t, confResp)
+		if client.BaseURL != "https://api.example.com" || client.Token != "secret" {
+			t.Fatalf("unexpected client settings: %s %s", client.BaseURL, client.Token)
+		}
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		t.Setenv("VAPI_API_KEY", "")
+		t.Setenv("VAPI_TOKEN", "")
+
+		confResp := configureProvider(t, ctx, map[string]attr.Value{"token": types.StringNull()})
+		if !confResp.Diagnostics.HasError() || confResp.ResourceData != nil {
+			t.Fatalf("expected missing token diagnostics")
+		}
+		if summary := confResp.Diagnostics.Errors()[0].Summary(); summary != "Missing Vapi API Token" {
+			t.Fatalf("unexpected diagnostic %q", summary)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		confResp := configureProvider(t, ctx, map[string]attr.Value{"token": types.StringUnknown()})
+		if !confResp.Diagnostics.HasError() || confResp.ResourceData != nil {
+			t.Fatalf("expected unknown token diagnostics")
+		}
 	})
+}
+
+func TestProviderConfigureTransport(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Team"); got != "voice" {
+			t.Errorf("expected extra header, got %q", got)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	confResp := configureProvider(t, ctx, map[string]attr.Value{
+		"url":                types.StringValue(server.URL),
+		"max_retries":        types.Int64Value(0),
+		"verify_credentials": types.BoolValue(true),
+		"request_timeout":    types.StringValue("5s"),
+		"ca_cert_pem":        types.StringValue(caPEM),
+		"extra_headers":      types.MapValueMust(types.StringType, map[string]attr.Value{"X-Team": types.StringValue("voice")}),
+	})
+	if confResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", confResp.Diagnostics)
+	}
+	if client := configuredClient(t, confResp); client.HTTPClient.Timeout != 5*time.Second {
+		t.Fatalf("unexpected timeout %s", client.HTTPClient.Timeout)
+	}
+
+	defaults := configuredClient(t, configureProvider(t, ctx, nil))
+	if defaults.HTTPClient.Timeout != vapi.DefaultRequestTimeout {
+		t.Fatalf("expected default timeout, got %s", defaults.HTTPClient.Timeout)
+	}
+
+	cases := map[string]struct {
+		overrides map[string]attr.Value
+		path      path.Path
+	}{
+		"invalid proxy":   {overrides: map[string]attr.Value{"proxy_url": types.StringValue("::")}, path: path.Root("proxy_url")},
+		"invalid CA":      {overrides: map[string]attr.Value{"ca_cert_pem": types.StringValue("garbage")}, path: path.Root("ca_cert_pem")},
+		"invalid timeout": {overrides: map[string]attr.Value{"request_timeout": types.StringValue("forever")}, path: path.Root("request_timeout")},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			confResp := configureProvider(t, ctx, tc.overrides)
+			if confResp.ResourceData != nil || diagAttributeError(tc.path, confResp) == nil {
+				t.Fatalf("expected attribute error at %s, got %v", tc.path, confResp.Diagnostics)
+			}
+		})
+	}
+}
+
+// diagAttributeError returns the first error diagnostic reported at p, or nil.
+func diagAttributeError(p path.Path, resp *frameworkProvider.ConfigureResponse) diag.Diagnostic {
+	for _, d := range resp.Diagnostics.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok && withPath.Path().Equal(p) {
+			return d
+		}
+	}
+	return nil
+}
+
+func configuredClient(t *testing.T, resp *frameworkProvider.ConfigureResponse) *vapi.APIClient {
+	t.Helper()
+
+	client, ok := resp.ResourceData.(*vapi.APIClient)
+	if !ok {
+		t.Fatalf("expected resource data to be *vapi.APIClient, got %T (%v)", resp.ResourceData, resp.Diagnostics)
+	}
+	return client
+}
+
+func TestProviderUserAgent(t *testing.T) {
+	cases := []struct {
+		version, terraformVersion, suffix, want string
+	}{
+		{version: "0.13.0", terraformVersion: "1.9.5", want: "terraform-provider-vapi/0.13.0 terraform/1.9.5"},
+		{version: "0.13.0", terraformVersion: "1.9.5", suffix: " infra-bot/2 ", want: "terraform-provider-vapi/0.13.0 terraform/1.9.5 infra-bot/2"},
+		{want: "terraform-provider-vapi/dev terraform/unknown"},
+	}
+	for _, tc := range cases {
+		got := (&VAPIProvider{version: tc.version}).userAgent(tc.terraformVersion, tc.suffix)
+		if got != tc.want {
+			t.Errorf("userAgent(%q, %q, %q) = %q, want %q", tc.version, tc.terraformVersion, tc.suffix, got, tc.want)
+		}
+	}
+
+	suffixed := configuredClient(t, configureProvider(t, context.Background(), map[string]attr.Value{
+		"user_agent_suffix": types.StringValue("infra-bot/2"),
+	}))
+	if suffixed.UserAgent != "terraform-provider-vapi/test terraform/unknown infra-bot/2" {
+		t.Fatalf("unexpected user agent %q", suffixed.UserAgent)
+	}
+}
+
+func TestProviderConfigureVerifiesCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/assistant" || r.URL.Query().Get("limit") != "1" {
+			t.Errorf("unexpected verification request %s", r.URL)
+		}
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Invalid Key. Hot tip, you may be using the private key instead of the public key, or vice versa.","error":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	ok := configureProvider(t, ctx, map[string]attr.Value{
+		"url":                types.StringValue(server.URL),
+		"token":              types.StringValue("good"),
+		"max_retries":        types.Int64Value(0),
+		"verify_credentials": types.BoolValue(true),
+	})
+	if ok.Diagnostics.HasError() || ok.ResourceData == nil {
+		t.Fatalf("unexpected diagnostics: %v", ok.Diagnostics)
+	}
+
+	bad := configureProvider(t, ctx, map[string]attr.Value{
+		"url":                types.StringValue(server.URL),
+		"token":              types.StringValue("bad"),
+		"max_retries":        types.Int64Value(0),
+		"verify_credentials": types.BoolValue(true),
+	})
+	if !bad.Diagnostics.HasError() || bad.ResourceData != nil {
+		t.Fatalf("expected invalid token diagnostics")
+	}
+	if summary := bad.Diagnostics.Errors()[0].Summary(); summary != "Invalid Vapi API Token" {
+		t.Fatalf("unexpected diagnostic %q", summary)
+	}
+}
+
+func buildProviderConfig(t *testing.T, ctx context.Context, overrides map[string]attr.Value) tftypes.Value {
+	var schemaResp frameworkProvider.SchemaResponse
+	(&VAPIProvider{}).Schema(ctx, frameworkProvider.SchemaRequest{}, &schemaResp)
+
+	attrTypes := make(map[string]attr.Type, len(schemaResp.Schema.Attributes))
+	values := make(map[string]attr.Value, len(schemaResp.Schema.Attributes))
+	for name, attribute := range schemaResp.Schema.Attributes {
+		attrTypes[name] = attribute.GetType()
+		values[name] = nullValueOf(t, ctx, attribute.GetType())
+	}
+	values["url"] = types.StringValue("https://api.example.com")
+	values["token"] = types.StringValue("secret")
+	for k, v := range overrides {
+		values[k] = v
+	}
+
+	obj, diags := types.ObjectValue(attrTypes, values)
 	if diags.HasError() {
 		t.Fatalf("object value diagnostics: %v", diags)
 	}
@@ -89,3 +377,13 @@ func buildProviderConfig(t *testing.T, ctx context.Context) tftypes.Value {
 
 	return value
 }
+
+func nullValueOf(t *testing.T, ctx context.Context, typ attr.Type) attr.Value {
+	t.Helper()
+
+	value, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(typ.TerraformType(ctx), nil))
+	if err != nil {
+		t.Fatalf("null value for %s: %v", typ, err)
+	}
+	return value
+}
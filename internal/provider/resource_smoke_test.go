@@ -18,6 +18,11 @@ func TestResourceSchemaAndMetadata(t *testing.T) {
 		NewVAPIAssistantResource(),
 		NewVAPIToolFunctionResource(),
 		NewVAPIToolQueryFunctionResource(),
+		NewVAPIToolTransferCallResource(),
+		NewVAPIToolEndCallResource(),
+		NewVAPIToolDTMFResource(),
+		NewVAPIToolSMSResource(),
+		NewVAPIToolVoicemailResource(),
 		NewVAPISIPTrunkResource(),
 		NewVAPISIPTrunkPhoneNumberResource(),
 		NewVAPIPhoneNumberResource(),
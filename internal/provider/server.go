@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+// ServerResourceModel is the webhook endpoint of an assistant, tool or phone
+// number.
+type ServerResourceModel struct {
+	URL            types.String                    `tfsdk:"url"`
+	Secret         types.String                    `tfsdk:"secret"`
+	TimeoutSeconds types.Int64                     `tfsdk:"timeout_seconds"`
+	Headers        types.Map                       `tfsdk:"headers"`
+	BackoffPlan    *ServerBackoffPlanResourceModel `tfsdk:"backoff_plan"`
+}
+
+type ServerBackoffPlanResourceModel struct {
+	Type             types.String  `tfsdk:"type"`
+	MaxRetries       types.Int64   `tfsdk:"max_retries"`
+	BaseDelaySeconds types.Float64 `tfsdk:"base_delay_seconds"`
+}
+
+// serverAttribute returns the schema of a `server` block.
+func serverAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				MarkdownDescription: "URL that receives the webhook requests.",
+				Required:            true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "Secret sent in the `X-Vapi-Secret` header. The API does not return it, so it is kept from configuration.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to wait for a response, between 1 and 300. Defaults to 20; the default is not stored in state.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 300),
+				},
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Additional headers sent with every request, for example for authentication.",
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"backoff_plan": schema.SingleNestedAttribute{
+				MarkdownDescription: "How failed requests are retried.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Backoff strategy: `fixed` or `exponential`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("fixed", "exponential"),
+						},
+					},
+					"max_retries": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of retries, between 0 and 10.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 10),
+						},
+					},
+					"base_delay_seconds": schema.Float64Attribute{
+						MarkdownDescription: "Delay before the first retry, between 0 and 10 seconds.",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 10),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildServer returns the server sent to the API. The deprecated flat url and
+// secret attributes are used when the block is not configured.
+func buildServer(server *ServerResourceModel, flatURL, flatSecret types.String) *vapi.Server {
+	if server == nil {
+		if flatURL.IsNull() || flatURL.IsUnknown() {
+			return nil
+		}
+		return &vapi.Server{URL: flatURL.ValueString(), Secret: flatSecret.ValueString()}
+	}
+
+	result := &vapi.Server{
+		URL:            server.URL.ValueString(),
+		Secret:         server.Secret.ValueString(),
+		TimeoutSeconds: server.TimeoutSeconds.ValueInt64(),
+	}
+	if !server.Headers.IsNull() && !server.Headers.IsUnknown() {
+		result.Headers = make(map[string]string, len(server.Headers.Elements()))
+		for name, value := range server.Headers.Elements() {
+			if str, ok := value.(types.String); ok {
+				result.Headers[name] = str.ValueString()
+			}
+		}
+	}
+	if plan := server.BackoffPlan; plan != nil {
+		result.BackoffPlan = &vapi.ServerBackoffPlan{
+			Type:             plan.Type.ValueString(),
+			MaxRetries:       valuePointer(plan.MaxRetries, plan.MaxRetries.ValueInt64),
+			BaseDelaySeconds: valuePointer(plan.BaseDelaySeconds, plan.BaseDelaySeconds.ValueFloat64),
+		}
+	}
+	return result
+}
+
+// serverValue wraps server for a request body. Removing a server that prior
+// state had sends an explicit null so the API detaches it.
+func serverValue(server *vapi.Server, hadServer bool) vapi.Nullable[*vapi.Server] {
+	return objectValue(server, hadServer)
+}
+
+// flattenServer maps the server from the API into whichever form the prior
+// state uses: the deprecated flat url and secret attributes when they are set,
+// the `server` block otherwise.
+func flattenServer(server *vapi.Server, block **ServerResourceModel, flatURL, flatSecret *types.String) {
+	if *block != nil || flatURL.IsNull() {
+		*block = flattenServerBlock(server, *block)
+		return
+	}
+
+	if server == nil || server.URL == "" {
+		*flatURL = types.StringNull()
+		*flatSecret = types.StringNull()
+		return
+	}
+	*flatURL = types.StringValue(server.URL)
+	if server.Secret != "" {
+		*flatSecret = types.StringValue(server.Secret)
+	}
+}
+
+// serverDefaultTimeoutSeconds is the timeout the API reports for servers that
+// do not set one.
+const serverDefaultTimeoutSeconds = 20
+
+// flattenServerBlock maps the server from the API into a `server` block. The
+// API does not return the secret, so it is carried over from prior unless the
+// response includes one. The default timeout is read back as null unless prior
+// set it, so a block without timeout_seconds does not change after apply.
+func flattenServerBlock(server *vapi.Server, prior *ServerResourceModel) *ServerResourceModel {
+	if server == nil || server.URL == "" {
+		return nil
+	}
+
+	result := &ServerResourceModel{
+		URL:            types.StringValue(server.URL),
+		Secret:         types.StringNull(),
+		TimeoutSeconds: types.Int64Null(),
+		Headers:        types.MapNull(types.StringType),
+	}
+	if server.Secret != "" {
+		result.Secret = types.StringValue(server.Secret)
+	} else if prior != nil {
+		result.Secret = prior.Secret
+	}
+	priorTimeout := prior != nil && !prior.TimeoutSeconds.IsNull()
+	if server.TimeoutSeconds != 0 && (server.TimeoutSeconds != serverDefaultTimeoutSeconds || priorTimeout) {
+		result.TimeoutSeconds = types.Int64Value(server.TimeoutSeconds)
+	}
+	if len(server.Headers) > 0 {
+		result.Headers, _ = types.MapValueFrom(context.Background(), types.StringType, server.Headers)
+	}
+	if plan := server.BackoffPlan; plan != nil {
+		result.BackoffPlan = &ServerBackoffPlanResourceModel{
+			Type:             types.StringValue(plan.Type),
+			MaxRetries:       types.Int64PointerValue(plan.MaxRetries),
+			BaseDelaySeconds: types.Float64PointerValue(plan.BaseDelaySeconds),
+		}
+	}
+	return result
+}
+
+// serverBlockUpgrader upgrades state written before the `server` block
+// existed by moving the flat url and secret attributes into the block. It
+// works on the raw JSON state so the prior schema does not have to be kept.
+func serverBlockUpgrader(urlAttribute, secretAttribute string) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", "The prior state is not available as JSON.")
+				return
+			}
+
+			var state map[string]json.RawMessage
+			if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", fmt.Sprintf("Failed to decode prior state: %s", err))
+				return
+			}
+
+			var url, secret *string
+			_ = json.Unmarshal(state[urlAttribute], &url)
+			_ = json.Unmarshal(state[secretAttribute], &secret)
+			if url != nil {
+				server, err := json.Marshal(map[string]*string{"url": url, "secret": secret})
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+					return
+				}
+				state["server"] = server
+				state[urlAttribute] = json.RawMessage("null")
+				state[secretAttribute] = json.RawMessage("null")
+			}
+
+			upgraded, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+func TestServerRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	block := &ServerResourceModel{
+		URL:            types.StringValue("https://hook.example.com"),
+		Secret:         types.StringValue("s3cret"),
+		TimeoutSeconds: types.Int64Value(45),
+		Headers:        types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("Bearer abc")}),
+		BackoffPlan: &ServerBackoffPlanResourceModel{
+			Type:             types.StringValue("exponential"),
+			MaxRetries:       types.Int64Value(0),
+			BaseDelaySeconds: types.Float64Null(),
+		},
+	}
+
+	server := buildServer(block, types.StringNull(), types.StringNull())
+	want := `{"url":"https://hook.example.com","secret":"s3cret","timeoutSeconds":45,"headers":{"Authorization":"Bearer abc"},"backoffPlan":{"type":"exponential","maxRetries":0}}`
+	if got := string(mustMarshal(t, server)); got != want {
+		t.Fatalf("request: got %s, want %s", got, want)
+	}
+
+	// The API does not return the secret.
+	server.Secret = ""
+	flattened := flattenServerBlock(server, block)
+	if !flattened.Secret.Equal(block.Secret) || !flattened.TimeoutSeconds.Equal(block.TimeoutSeconds) ||
+		!flattened.Headers.Equal(block.Headers) || *flattened.BackoffPlan != *block.BackoffPlan {
+		t.Fatalf("unexpected flattened server: %+v", flattened)
+	}
+
+	if got := buildServer(nil, types.StringValue("https://legacy.example.com"), types.StringValue("old")); got.URL != "https://legacy.example.com" || got.Secret != "old" {
+		t.Fatalf("expected flat attributes to be used without a block, got %+v", got)
+	}
+	if buildServer(nil, types.StringNull(), types.StringNull()) != nil {
+		t.Fatal("expected no server without a block or url")
+	}
+}
+
+func TestFlattenServerKeepsConfiguredForm(t *testing.T) {
+	t.Parallel()
+
+	response := &vapi.Server{URL: "https://hook.example.com/v2"}
+
+	var block *ServerResourceModel
+	flatURL, flatSecret := types.StringValue("https://hook.example.com"), types.StringValue("s3cret")
+	flattenServer(response, &block, &flatURL, &flatSecret)
+	if block != nil || flatURL.ValueString() != "https://hook.example.com/v2" || flatSecret.ValueString() != "s3cret" {
+		t.Fatalf("expected deprecated attributes to be kept, got %+v %s %s", block, flatURL, flatSecret)
+	}
+
+	removedURL, removedSecret := types.StringValue("https://hook.example.com"), types.StringValue("s3cret")
+	flattenServer(nil, &block, &removedURL, &removedSecret)
+	if block != nil || !removedURL.IsNull() || !removedSecret.IsNull() {
+		t.Fatalf("expected a removed server to clear the deprecated attributes, got %+v %s %s", block, removedURL, removedSecret)
+	}
+
+	flatURL, flatSecret = types.StringNull(), types.StringNull()
+	flattenServer(response, &block, &flatURL, &flatSecret)
+	if block == nil || block.URL.ValueString() != "https://hook.example.com/v2" || !flatURL.IsNull() {
+		t.Fatalf("expected server block, got %+v %s", block, flatURL)
+	}
+
+	flattenServer(nil, &block, &flatURL, &flatSecret)
+	if block != nil {
+		t.Fatalf("expected removed server to read back as null, got %+v", block)
+	}
+}
+
+func TestAssistantRequestDetachesRemovedServer(t *testing.T) {
+	t.Parallel()
+
+	prior := VAPIAssistantResourceModel{Server: &ServerResourceModel{URL: types.StringValue("https://hook.example.com")}}
+	if got, _ := jsonAtPath(t, mapVAPIAssistantRequest(&VAPIAssistantResourceModel{}, &prior), "server"); got != "null" {
+		t.Fatalf("expected explicit null server, got %s", got)
+	}
+	if _, ok := jsonAtPath(t, mapVAPIAssistantRequest(&VAPIAssistantResourceModel{}, nil), "server"); ok {
+		t.Fatal("expected server to be omitted on create")
+	}
+}
+
+func TestServerBlockStateUpgrade(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		resource resource.ResourceWithUpgradeState
+		prior    string
+		check    func(t *testing.T, state tfsdk.State)
+	}{
+		"assistant": {
+			resource: &VAPIAssistantResource{},
+			prior:    `{"id":"assistant-1","name":"assistant","server_url":"https://hook.example.com","server_url_secret":"s3cret"}`,
+			check: func(t *testing.T, state tfsdk.State) {
+				var model VAPIAssistantResourceModel
+				if diags := state.Get(context.Background(), &model); diags.HasError() {
+					t.Fatalf("state.Get diagnostics: %v", diags)
+				}
+				if !model.ServerURL.IsNull() || !model.ServerURLSecret.IsNull() || model.Server == nil ||
+					model.Server.URL.ValueString() != "https://hook.example.com" || model.Server.Secret.ValueString() != "s3cret" {
+					t.Fatalf("unexpected upgraded state: %+v / %s", model.Server, model.ServerURL)
+				}
+			},
+		},
+		"assistant without server": {
+			resource: &VAPIAssistantResource{},
+			prior:    `{"id":"assistant-1","name":"assistant","server_url":null,"server_url_secret":null}`,
+			check: func(t *testing.T, state tfsdk.State) {
+				var model VAPIAssistantResourceModel
+				if diags := state.Get(context.Background(), &model); diags.HasError() {
+					t.Fatalf("state.Get diagnostics: %v", diags)
+				}
+				if model.Server != nil || model.Name.ValueString() != "assistant" {
+					t.Fatalf("unexpected upgraded state: %+v", model)
+				}
+			},
+		},
+		"tool function": {
+			resource: &VAPIToolFunctionResource{},
+			prior:    `{"id":"tool-1","name":"lookup","server_url":"https://tools.example.com","server_secret":null}`,
+			check: func(t *testing.T, state tfsdk.State) {
+				var server *ServerResourceModel
+				if diags := state.GetAttribute(context.Background(), path.Root("server"), &server); diags.HasError() {
+					t.Fatalf("state.GetAttribute diagnostics: %v", diags)
+				}
+				if server == nil || server.URL.ValueString() != "https://tools.example.com" || !server.Secret.IsNull() {
+					t.Fatalf("unexpected upgraded server: %+v", server)
+				}
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var schemaResp resource.SchemaResponse
+			tc.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			if schemaResp.Schema.Version != 1 {
+				t.Fatalf("expected schema version 1, got %d", schemaResp.Schema.Version)
+			}
+
+			upgrader := tc.resource.UpgradeState(ctx)[0]
+			resp := &resource.UpgradeStateResponse{}
+			upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(tc.prior)}}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("upgrade diagnostics: %v", resp.Diagnostics)
+			}
+
+			raw, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("upgraded state does not match the schema: %v", err)
+			}
+			tc.check(t, tfsdk.State{Schema: schemaResp.Schema, Raw: raw})
+		})
+	}
+}
+
+func TestServerBlockUpgraderRejectsInvalidState(t *testing.T) {
+	t.Parallel()
+
+	resp := &resource.UpgradeStateResponse{}
+	serverBlockUpgrader("server_url", "server_url_secret").StateUpgrader(context.Background(), resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: json.RawMessage(`[`)},
+	}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for malformed state")
+	}
+}
+
+func TestServerDefaultTimeoutRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// The API reports its default timeout for servers that do not set one.
+	payload := []byte(`{
+		"id": "tool-1",
+		"orgId": "org-1",
+		"type": "function",
+		"async": false,
+		"server": {"url": "https://tools.example.com", "timeoutSeconds": 20},
+		"function": {"name": "lookup", "description": "desc"}
+	}`)
+	transport := &queueRoundTripper{
+		t: t,
+		responses: []queuedResponse{
+			{method: http.MethodPost, path: "/tool", status: 201, body: payload, wantBody: `{"server":{"url":"https://tools.example.com"},"function":{"description":"desc","name":"lookup"},"type":"function","async":false}`},
+			{method: http.MethodGet, path: "/tool/tool-1", status: 200, body: payload},
+		},
+	}
+
+	res := &VAPIToolFunctionResource{
+		client: &vapi.APIClient{BaseURL: "https://api.example.com", Token: "token", HTTPClient: &http.Client{Transport: transport}},
+	}
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	planned := &ServerResourceModel{URL: types.StringValue("https://tools.example.com"), Secret: types.StringNull(), TimeoutSeconds: types.Int64Null(), Headers: types.MapNull(types.StringType)}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, VAPIToolFunctionResourceModel{
+		ID:             types.StringUnknown(),
+		OrgID:          types.StringUnknown(),
+		CreatedAt:      types.StringUnknown(),
+		UpdatedAt:      types.StringUnknown(),
+		Name:           types.StringValue("lookup"),
+		Description:    types.StringValue("desc"),
+		Async:          types.BoolValue(false),
+		Type:           types.StringValue("function"),
+		Server:         planned,
+		ParametersJSON: normalizedJSON(nil),
+	}); diags.HasError() {
+		t.Fatalf("plan diagnostics: %v", diags)
+	}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	for name, state := range map[string]tfsdk.State{"create": createResp.State, "read": readResp.State} {
+		var server *ServerResourceModel
+		if diags := state.GetAttribute(ctx, path.Root("server"), &server); diags.HasError() {
+			t.Fatalf("%s: state diagnostics: %v", name, diags)
+		}
+		if server == nil || !server.TimeoutSeconds.IsNull() || !server.URL.Equal(planned.URL) {
+			t.Fatalf("%s: expected the planned server block, got %+v", name, server)
+		}
+	}
+	transport.assertDrained()
+
+	// An explicitly configured default is kept.
+	planned.TimeoutSeconds = types.Int64Value(20)
+	if got := flattenServerBlock(&vapi.Server{URL: "https://tools.example.com", TimeoutSeconds: 20}, planned); !got.TimeoutSeconds.Equal(types.Int64Value(20)) {
+		t.Fatalf("expected configured timeout to be kept, got %s", got.TimeoutSeconds)
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
 )
 
 // ListValueFromStrings converts a slice of strings to a Terraform types.List.
@@ -24,3 +25,85 @@ func ElementsAsString(list types.List) []string {
 	}
 	return result
 }
+
+// valuePointer returns a pointer to the value of v, or nil when v is null or
+// unknown.
+func valuePointer[T any](v attr.Value, value func() T) *T {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	out := value()
+	return &out
+}
+
+// nullableValue converts a planned attribute into a tri-state request field.
+// Unknown values are omitted so the API picks its default. Null values are
+// sent as an explicit null when prior held a value, which resets an attribute
+// that was removed from configuration, and are omitted otherwise.
+func nullableValue[T any](planned, prior attr.Value, value func() T) vapi.Nullable[T] {
+	switch {
+	case planned.IsUnknown():
+		return vapi.Nullable[T]{}
+	case planned.IsNull():
+		if prior != nil && !prior.IsNull() && !prior.IsUnknown() {
+			return vapi.ExplicitNull[T]()
+		}
+		return vapi.Nullable[T]{}
+	}
+	return vapi.NewNullable(value())
+}
+
+// computedNullableValue is nullableValue for Optional and Computed attributes
+// without a plan modifier. Terraform plans those as unknown once they are
+// removed from configuration, so an unknown value is sent as an explicit null
+// when prior held one, and the API goes back to its default.
+func computedNullableValue[T any](planned, prior attr.Value, value func() T) vapi.Nullable[T] {
+	if planned.IsUnknown() && prior != nil && !prior.IsNull() && !prior.IsUnknown() {
+		return vapi.ExplicitNull[T]()
+	}
+	return nullableValue(planned, prior, value)
+}
+
+// objectValue wraps a nested block for a request body. Removing a block that
+// prior state had sends an explicit null so the API drops it.
+func objectValue[T any](value *T, hadValue bool) vapi.Nullable[*T] {
+	switch {
+	case value != nil:
+		return vapi.NewNullable(value)
+	case hadValue:
+		return vapi.ExplicitNull[*T]()
+	default:
+		return vapi.Nullable[*T]{}
+	}
+}
+
+// sliceValue wraps a list for a request body. Removing every element that
+// prior state had sends an empty list so the API clears them.
+func sliceValue[T any](values []T, hadValues bool) vapi.Nullable[[]T] {
+	switch {
+	case len(values) > 0:
+		return vapi.NewNullable(values)
+	case hadValues:
+		return vapi.NewNullable([]T{})
+	default:
+		return vapi.Nullable[[]T]{}
+	}
+}
+
+// stringValueOrNull returns a null string for "", which the API uses for
+// optional fields that are not set.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// listValueOrNull returns a null list for an empty or missing slice, which
+// the API omits for optional lists that are not set.
+func listValueOrNull(values []string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	return ListValueFromStrings(values)
+}
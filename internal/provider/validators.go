@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// siblingValueValidator rejects an attribute unless a sibling attribute holds
+// one of values. Settings such as voice speed or style only exist for some
+// providers, and hook action fields only for some action types; the API
+// rejects them otherwise.
+type siblingValueValidator struct {
+	sibling string
+	values  []string
+}
+
+var (
+	_ validator.Bool    = siblingValueValidator{}
+	_ validator.Float64 = siblingValueValidator{}
+	_ validator.Int64   = siblingValueValidator{}
+	_ validator.Map     = siblingValueValidator{}
+	_ validator.Object  = siblingValueValidator{}
+	_ validator.String  = siblingValueValidator{}
+)
+
+// supportedByProviders returns a validator allowing the attribute only when
+// the sibling `provider` attribute is one of providers.
+func supportedByProviders(providers ...string) siblingValueValidator {
+	return siblingValueValidator{sibling: "provider", values: providers}
+}
+
+// supportedByTypes returns a validator allowing the attribute only when the
+// sibling `type` attribute is one of values.
+func supportedByTypes(values ...string) siblingValueValidator {
+	return siblingValueValidator{sibling: "type", values: values}
+}
+
+func (v siblingValueValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("only supported when %s is one of: %s", v.sibling, strings.Join(v.values, ", "))
+}
+
+func (v siblingValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v siblingValueValidator) validate(ctx context.Context, config tfsdk.Config, attributePath path.Path, value attr.Value, diags *diag.Diagnostics) {
+	if value.IsNull() {
+		return
+	}
+
+	var sibling types.String
+	diags.Append(config.GetAttribute(ctx, attributePath.ParentPath().AtName(v.sibling), &sibling)...)
+	if diags.HasError() || sibling.IsNull() || sibling.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(v.values, sibling.ValueString()) {
+		diags.AddAttributeError(
+			attributePath,
+			"Unsupported attribute",
+			fmt.Sprintf("Attribute %s is not supported when %s is %q; it is %s.", attributePath, v.sibling, sibling.ValueString(), v.Description(ctx)),
+		)
+	}
+}
+
+func (v siblingValueValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v siblingValueValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v siblingValueValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v siblingValueValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v siblingValueValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v siblingValueValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+// destinationAddressValidator requires the attribute holding the address of
+// a transfer destination's `type`, such as `sip_uri` for `sip` destinations.
+// Types missing from required need no address.
+type destinationAddressValidator struct {
+	required map[string]string
+}
+
+var _ validator.Object = destinationAddressValidator{}
+
+func (v destinationAddressValidator) Description(_ context.Context) string {
+	pairs := make([]string, 0, len(v.required))
+	for _, value := range slices.Sorted(maps.Keys(v.required)) {
+		pairs = append(pairs, fmt.Sprintf("%s for %s", v.required[value], value))
+	}
+	return "requires " + strings.Join(pairs, ", ")
+}
+
+func (v destinationAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v destinationAddressValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var objectType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.AtName("type"), &objectType)...)
+	attribute, ok := v.required[objectType.ValueString()]
+	if resp.Diagnostics.HasError() || !ok {
+		return
+	}
+
+	var value types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.AtName(attribute), &value)...)
+	if value.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName(attribute),
+			"Missing required attribute",
+			fmt.Sprintf("Attribute %s is required for %s destinations.", req.Path.AtName(attribute), objectType.ValueString()),
+		)
+	}
+}
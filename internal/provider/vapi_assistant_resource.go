@@ -4,14 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+	"strings"
 )
 
 var _ resource.Resource = &VAPIAssistantResource{}
+var _ resource.ResourceWithImportState = &VAPIAssistantResource{}
+var _ resource.ResourceWithUpgradeState = &VAPIAssistantResource{}
 
 func NewVAPIAssistantResource() resource.Resource {
 	return &VAPIAssistantResource{}
@@ -22,65 +33,167 @@ type VAPIAssistantResource struct {
 }
 
 type VAPIAssistantResourceModel struct {
-	ID                           types.String                    `tfsdk:"id"`
-	OrgID                        types.String                    `tfsdk:"org_id"`
-	Name                         types.String                    `tfsdk:"name"`
-	FirstMessageMode             types.String                    `tfsdk:"first_message_mode"`
-	HipaaEnabled                 types.Bool                      `tfsdk:"hipaa_enabled"`
-	ClientMessages               types.List                      `tfsdk:"client_messages"`
-	ServerMessages               types.List                      `tfsdk:"server_messages"`
-	SilenceTimeoutSeconds        types.Float64                   `tfsdk:"silence_timeout_seconds"`
-	MaxDurationSeconds           types.Int64                     `tfsdk:"max_duration_seconds"`
-	BackgroundSound              types.String                    `tfsdk:"background_sound"`
-	BackgroundDenoising          types.Bool                      `tfsdk:"background_denoising"`
-	ModelOutputEnabled           types.Bool                      `tfsdk:"model_output_enabled"`
-	FirstMessage                 types.String                    `tfsdk:"first_message"`
-	VoicemailMessage             types.String                    `tfsdk:"voicemail_message"`
-	EndCallMessage               types.String                    `tfsdk:"end_call_message"`
-	ServerURL                    types.String                    `tfsdk:"server_url"`
-	ServerURLSecret              types.String                    `tfsdk:"server_url_secret"`
-	EndCallPhrases               types.List                      `tfsdk:"end_call_phrases"`
-	Transcriber                  *TranscriberResourceModel       `tfsdk:"transcriber"`
-	Model                        *ModelResourceModel             `tfsdk:"model"`
-	Voice                        *VoiceResourceModel             `tfsdk:"voice"`
-	StartSpeakingPlan            *StartSpeakingPlanResourceModel `tfsdk:"start_speaking_plan"`
-	StopSpeakingPlan             *StopSpeakingPlanResourceModel  `tfsdk:"stop_speaking_plan"`
-	AnalysisPlan                 *AnalysisPlanResourceModel      `tfsdk:"analysis_plan"`
-	MessagePlan                  *MessagePlanResourceModel       `tfsdk:"message_plan"`
-	ArtifactPlan                 *ArtifactPlanResourceModel      `tfsdk:"artifact_plan"`
-	EndCallFunctionEnabled       types.Bool                      `tfsdk:"end_call_function_enabled"`
-	RecordingEnabled             types.Bool                      `tfsdk:"recording_enabled"`
-	ForwardingPhoneNumber        types.String                    `tfsdk:"forwarding_phone_number"`
-	PhoneNumberID                types.String                    `tfsdk:"phone_number_id"`
-	Language                     types.String                    `tfsdk:"language"`
-	InterruptionsEnabled         types.Bool                      `tfsdk:"interruptions_enabled"`
-	DialKeypadFunctionEnabled    types.Bool                      `tfsdk:"dial_keypad_function_enabled"`
-	FillersEnabled               types.Bool                      `tfsdk:"fillers_enabled"`
-	ResponseDelaySeconds         types.Float64                   `tfsdk:"response_delay_seconds"`
-	NumWordsToInterruptAssistant types.Int64                     `tfsdk:"num_words_to_interrupt_assistant"`
-	LiveTranscriptsEnabled       types.Bool                      `tfsdk:"live_transcripts_enabled"`
-	Keywords                     types.List                      `tfsdk:"keywords"`
-	ParentID                     types.String                    `tfsdk:"parent_id"`
+	ID                           types.String                     `tfsdk:"id"`
+	OrgID                        types.String                     `tfsdk:"org_id"`
+	Name                         types.String                     `tfsdk:"name"`
+	FirstMessageMode             types.String                     `tfsdk:"first_message_mode"`
+	HipaaEnabled                 types.Bool                       `tfsdk:"hipaa_enabled"`
+	ClientMessages               types.List                       `tfsdk:"client_messages"`
+	ServerMessages               types.List                       `tfsdk:"server_messages"`
+	SilenceTimeoutSeconds        types.Float64                    `tfsdk:"silence_timeout_seconds"`
+	MaxDurationSeconds           types.Int64                      `tfsdk:"max_duration_seconds"`
+	BackgroundSound              types.String                     `tfsdk:"background_sound"`
+	BackgroundDenoising          types.Bool                       `tfsdk:"background_denoising"`
+	ModelOutputEnabled           types.Bool                       `tfsdk:"model_output_enabled"`
+	FirstMessage                 types.String                     `tfsdk:"first_message"`
+	VoicemailMessage             types.String                     `tfsdk:"voicemail_message"`
+	EndCallMessage               types.String                     `tfsdk:"end_call_message"`
+	ServerURL                    types.String                     `tfsdk:"server_url"`
+	ServerURLSecret              types.String                     `tfsdk:"server_url_secret"`
+	Server                       *ServerResourceModel             `tfsdk:"server"`
+	EndCallPhrases               types.List                       `tfsdk:"end_call_phrases"`
+	Transcriber                  *TranscriberResourceModel        `tfsdk:"transcriber"`
+	Model                        *ModelResourceModel              `tfsdk:"model"`
+	Voice                        *VoiceResourceModel              `tfsdk:"voice"`
+	StartSpeakingPlan            *StartSpeakingPlanResourceModel  `tfsdk:"start_speaking_plan"`
+	StopSpeakingPlan             *StopSpeakingPlanResourceModel   `tfsdk:"stop_speaking_plan"`
+	AnalysisPlan                 *AnalysisPlanResourceModel       `tfsdk:"analysis_plan"`
+	MessagePlan                  *MessagePlanResourceModel        `tfsdk:"message_plan"`
+	ArtifactPlan                 *ArtifactPlanResourceModel       `tfsdk:"artifact_plan"`
+	EndCallFunctionEnabled       types.Bool                       `tfsdk:"end_call_function_enabled"`
+	RecordingEnabled             types.Bool                       `tfsdk:"recording_enabled"`
+	ForwardingPhoneNumber        types.String                     `tfsdk:"forwarding_phone_number"`
+	PhoneNumberID                types.String                     `tfsdk:"phone_number_id"`
+	Language                     types.String                     `tfsdk:"language"`
+	InterruptionsEnabled         types.Bool                       `tfsdk:"interruptions_enabled"`
+	DialKeypadFunctionEnabled    types.Bool                       `tfsdk:"dial_keypad_function_enabled"`
+	FillersEnabled               types.Bool                       `tfsdk:"fillers_enabled"`
+	ResponseDelaySeconds         types.Float64                    `tfsdk:"response_delay_seconds"`
+	NumWordsToInterruptAssistant types.Int64                      `tfsdk:"num_words_to_interrupt_assistant"`
+	LiveTranscriptsEnabled       types.Bool                       `tfsdk:"live_transcripts_enabled"`
+	Keywords                     types.List                       `tfsdk:"keywords"`
+	ParentID                     types.String                     `tfsdk:"parent_id"`
+	VoicemailDetection           *VoicemailDetectionResourceModel `tfsdk:"voicemail_detection"`
+	KeypadInputPlan              *KeypadInputPlanResourceModel    `tfsdk:"keypad_input_plan"`
+	Hooks                        []HookResourceModel              `tfsdk:"hooks"`
+}
+
+type VoicemailDetectionResourceModel struct {
+	Provider                types.String                                `tfsdk:"provider"`
+	MachineDetectionTimeout types.Int64                                 `tfsdk:"machine_detection_timeout"`
+	BackoffPlan             *VoicemailDetectionBackoffPlanResourceModel `tfsdk:"backoff_plan"`
+}
+
+type VoicemailDetectionBackoffPlanResourceModel struct {
+	StartAtSeconds   types.Float64 `tfsdk:"start_at_seconds"`
+	FrequencySeconds types.Float64 `tfsdk:"frequency_seconds"`
+	MaxRetries       types.Int64   `tfsdk:"max_retries"`
+}
+
+type KeypadInputPlanResourceModel struct {
+	Enabled        types.Bool    `tfsdk:"enabled"`
+	TimeoutSeconds types.Float64 `tfsdk:"timeout_seconds"`
+	Delimiters     types.List    `tfsdk:"delimiters"`
+}
+
+type HookResourceModel struct {
+	On      types.String              `tfsdk:"on"`
+	Do      []HookActionResourceModel `tfsdk:"do"`
+	Options *HookOptionsResourceModel `tfsdk:"options"`
+}
+
+type HookActionResourceModel struct {
+	Type        types.String                  `tfsdk:"type"`
+	Exact       types.String                  `tfsdk:"exact"`
+	Prompt      types.String                  `tfsdk:"prompt"`
+	Destination *HookDestinationResourceModel `tfsdk:"destination"`
+}
+
+type HookDestinationResourceModel struct {
+	Type                   types.String `tfsdk:"type"`
+	Number                 types.String `tfsdk:"number"`
+	Extension              types.String `tfsdk:"extension"`
+	CallerID               types.String `tfsdk:"caller_id"`
+	NumberE164CheckEnabled types.Bool   `tfsdk:"number_e164_check_enabled"`
+	SipURI                 types.String `tfsdk:"sip_uri"`
+	SipHeaders             types.Map    `tfsdk:"sip_headers"`
+	Message                types.String `tfsdk:"message"`
+	Description            types.String `tfsdk:"description"`
+}
+
+type HookOptionsResourceModel struct {
+	TimeoutSeconds   types.Float64 `tfsdk:"timeout_seconds"`
+	TriggerMaxCount  types.Int64   `tfsdk:"trigger_max_count"`
+	TriggerResetMode types.String  `tfsdk:"trigger_reset_mode"`
 }
 
 type TranscriberResourceModel struct {
+	Provider     types.String                          `tfsdk:"provider"`
+	Model        types.String                          `tfsdk:"model"`
+	Language     types.String                          `tfsdk:"language"`
+	FallbackPlan *TranscriberFallbackPlanResourceModel `tfsdk:"fallback_plan"`
+}
+
+type TranscriberFallbackPlanResourceModel struct {
+	Transcribers []FallbackTranscriberResourceModel `tfsdk:"transcribers"`
+}
+
+type FallbackTranscriberResourceModel struct {
 	Provider types.String `tfsdk:"provider"`
 	Model    types.String `tfsdk:"model"`
 	Language types.String `tfsdk:"language"`
 }
 
 type ArtifactPlanResourceModel struct {
-	RecordingFormat types.String `tfsdk:"recording_format"`
+	RecordingFormat       types.String                 `tfsdk:"recording_format"`
+	RecordingPath         types.String                 `tfsdk:"recording_path"`
+	VideoRecordingEnabled types.Bool                   `tfsdk:"video_recording_enabled"`
+	PcapEnabled           types.Bool                   `tfsdk:"pcap_enabled"`
+	TranscriptPlan        *TranscriptPlanResourceModel `tfsdk:"transcript_plan"`
+}
+
+type TranscriptPlanResourceModel struct {
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	AssistantName types.String `tfsdk:"assistant_name"`
+	UserName      types.String `tfsdk:"user_name"`
 }
 
 type ModelResourceModel struct {
-	Model         types.String                `tfsdk:"model"`
-	SystemPrompt  types.String                `tfsdk:"system_prompt"`
-	Provider      types.String                `tfsdk:"provider"`
-	Temperature   types.Float64               `tfsdk:"temperature"`
-	MaxTokens     types.Int64                 `tfsdk:"max_tokens"`
-	ToolIDs       types.List                  `tfsdk:"tool_ids"`
-	KnowledgeBase *KnowledgeBaseResourceModel `tfsdk:"knowledge_base"`
+	Model          types.String                 `tfsdk:"model"`
+	SystemPrompt   types.String                 `tfsdk:"system_prompt"`
+	Provider       types.String                 `tfsdk:"provider"`
+	Temperature    types.Float64                `tfsdk:"temperature"`
+	MaxTokens      types.Int64                  `tfsdk:"max_tokens"`
+	ToolIDs        types.List                   `tfsdk:"tool_ids"`
+	Messages       []ModelMessageResourceModel  `tfsdk:"messages"`
+	Tools          []ModelToolResourceModel     `tfsdk:"tools"`
+	FallbackModels []FallbackModelResourceModel `tfsdk:"fallback_models"`
+	KnowledgeBase  *KnowledgeBaseResourceModel  `tfsdk:"knowledge_base"`
+}
+
+type FallbackModelResourceModel struct {
+	Provider types.String `tfsdk:"provider"`
+	Model    types.String `tfsdk:"model"`
+}
+
+type ModelToolResourceModel struct {
+	Type         types.String               `tfsdk:"type"`
+	Async        types.Bool                 `tfsdk:"async"`
+	Function     *ModelToolFunctionModel    `tfsdk:"function"`
+	Messages     []ToolMessageResourceModel `tfsdk:"messages"`
+	Destinations []Destination              `tfsdk:"destinations"`
+	Server       *ServerResourceModel       `tfsdk:"server"`
+}
+
+type ModelToolFunctionModel struct {
+	Name        types.String                     `tfsdk:"name"`
+	Description types.String                     `tfsdk:"description"`
+	Parameters  *FunctionParametersResourceModel `tfsdk:"parameters"`
+}
+
+type ModelMessageResourceModel struct {
+	Role    types.String `tfsdk:"role"`
+	Content types.String `tfsdk:"content"`
 }
 
 type KnowledgeBaseResourceModel struct {
@@ -90,9 +203,36 @@ type KnowledgeBaseResourceModel struct {
 }
 
 type VoiceResourceModel struct {
-	Model           types.String  `tfsdk:"model"`
+	Model           types.String                    `tfsdk:"model"`
+	Provider        types.String                    `tfsdk:"provider"`
+	VoiceID         types.String                    `tfsdk:"voice_id"`
+	Stability       types.Float64                   `tfsdk:"stability"`
+	SimilarityBoost types.Float64                   `tfsdk:"similarity_boost"`
+	FallbackPlan    *VoiceFallbackPlanResourceModel `tfsdk:"fallback_plan"`
+
+	Speed                     types.Float64           `tfsdk:"speed"`
+	Style                     types.Float64           `tfsdk:"style"`
+	Emotion                   types.String            `tfsdk:"emotion"`
+	Language                  types.String            `tfsdk:"language"`
+	OptimizeStreamingLatency  types.Int64             `tfsdk:"optimize_streaming_latency"`
+	InputPreprocessingEnabled types.Bool              `tfsdk:"input_preprocessing_enabled"`
+	ChunkPlan                 *ChunkPlanResourceModel `tfsdk:"chunk_plan"`
+}
+
+type ChunkPlanResourceModel struct {
+	Enabled               types.Bool  `tfsdk:"enabled"`
+	MinCharacters         types.Int64 `tfsdk:"min_characters"`
+	PunctuationBoundaries types.List  `tfsdk:"punctuation_boundaries"`
+}
+
+type VoiceFallbackPlanResourceModel struct {
+	Voices []FallbackVoiceResourceModel `tfsdk:"voices"`
+}
+
+type FallbackVoiceResourceModel struct {
 	Provider        types.String  `tfsdk:"provider"`
 	VoiceID         types.String  `tfsdk:"voice_id"`
+	Model           types.String  `tfsdk:"model"`
 	Stability       types.Float64 `tfsdk:"stability"`
 	SimilarityBoost types.Float64 `tfsdk:"similarity_boost"`
 }
@@ -116,11 +256,35 @@ type StopSpeakingPlanResourceModel struct {
 }
 
 type AnalysisPlanResourceModel struct {
-	SummaryPrompt           types.String                       `tfsdk:"summary_prompt"`
-	StructuredDataPrompt    types.String                       `tfsdk:"structured_data_prompt"`
-	StructuredDataSchema    *StructuredDataSchemaResourceModel `tfsdk:"structured_data_schema"`
-	SuccessEvaluationPrompt types.String                       `tfsdk:"success_evaluation_prompt"`
-	SuccessEvaluationRubric types.String                       `tfsdk:"success_evaluation_rubric"`
+	SummaryPrompt            types.String                        `tfsdk:"summary_prompt"`
+	StructuredDataPrompt     types.String                        `tfsdk:"structured_data_prompt"`
+	StructuredDataSchema     *StructuredDataSchemaResourceModel  `tfsdk:"structured_data_schema"`
+	StructuredDataSchemaJSON jsontypes.Normalized                `tfsdk:"structured_data_schema_json"`
+	SuccessEvaluationPrompt  types.String                        `tfsdk:"success_evaluation_prompt"`
+	SuccessEvaluationRubric  types.String                        `tfsdk:"success_evaluation_rubric"`
+	SummaryPlan              *SummaryPlanResourceModel           `tfsdk:"summary_plan"`
+	StructuredDataPlan       *StructuredDataPlanResourceModel    `tfsdk:"structured_data_plan"`
+	SuccessEvaluationPlan    *SuccessEvaluationPlanResourceModel `tfsdk:"success_evaluation_plan"`
+}
+
+type SummaryPlanResourceModel struct {
+	Messages       []ModelMessageResourceModel `tfsdk:"messages"`
+	Enabled        types.Bool                  `tfsdk:"enabled"`
+	TimeoutSeconds types.Float64               `tfsdk:"timeout_seconds"`
+}
+
+type StructuredDataPlanResourceModel struct {
+	Messages       []ModelMessageResourceModel `tfsdk:"messages"`
+	Enabled        types.Bool                  `tfsdk:"enabled"`
+	SchemaJSON     jsontypes.Normalized        `tfsdk:"schema_json"`
+	TimeoutSeconds types.Float64               `tfsdk:"timeout_seconds"`
+}
+
+type SuccessEvaluationPlanResourceModel struct {
+	Rubric         types.String                `tfsdk:"rubric"`
+	Messages       []ModelMessageResourceModel `tfsdk:"messages"`
+	Enabled        types.Bool                  `tfsdk:"enabled"`
+	TimeoutSeconds types.Float64               `tfsdk:"timeout_seconds"`
 }
 
 type StructuredDataSchemaResourceModel struct {
@@ -146,6 +310,7 @@ func (r *VAPIAssistantResource) Metadata(ctx context.Context, req resource.Metad
 func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an assistant resource in the VAPI system.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Unique identifier for the assistant resource.",
@@ -214,11 +379,20 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 			"server_url": schema.StringAttribute{
 				MarkdownDescription: "Server URL.",
 				Optional:            true,
+				DeprecationMessage:  "Use server.url instead.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("server")),
+				},
 			},
 			"server_url_secret": schema.StringAttribute{
 				MarkdownDescription: "Server URL Secret.",
 				Optional:            true,
+				DeprecationMessage:  "Use server.secret instead.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("server")),
+				},
 			},
+			"server": serverAttribute("Webhook that receives assistant events and tool calls."),
 			"end_call_phrases": schema.ListAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "List of phrases to end the call.",
@@ -240,6 +414,44 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 						MarkdownDescription: "Language used for transcription.",
 						Optional:            true,
 					},
+					"fallback_plan": schema.SingleNestedAttribute{
+						MarkdownDescription: "Transcribers to fall back to when the primary transcriber fails.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"transcribers": schema.ListNestedAttribute{
+								MarkdownDescription: "Fallback transcribers, tried in order.",
+								Required:            true,
+								Validators: []validator.List{
+									listvalidator.SizeAtLeast(1),
+								},
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"provider": schema.StringAttribute{
+											MarkdownDescription: "Provider for the transcriber service.",
+											Required:            true,
+											Validators: []validator.String{
+												stringvalidator.OneOf(transcriberProviders...),
+											},
+										},
+										"model": schema.StringAttribute{
+											MarkdownDescription: "Model used for transcription. Not supported by `custom-transcriber`.",
+											Optional:            true,
+											Validators: []validator.String{
+												supportedByProviders(transcriberSettingProviders...),
+											},
+										},
+										"language": schema.StringAttribute{
+											MarkdownDescription: "Language used for transcription. Not supported by `custom-transcriber`.",
+											Optional:            true,
+											Validators: []validator.String{
+												supportedByProviders(transcriberSettingProviders...),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 			"model": schema.SingleNestedAttribute{
@@ -251,8 +463,27 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 						Required:            true,
 					},
 					"system_prompt": schema.StringAttribute{
-						MarkdownDescription: "Prompt text used to guide the assistant model.",
+						MarkdownDescription: "Prompt text used to guide the assistant model. It is sent as a leading `system` message, ahead of `messages`.",
+						Optional:            true,
+					},
+					"messages": schema.ListNestedAttribute{
+						MarkdownDescription: "Prompt messages sent to the model in order, after `system_prompt` when that is set. A leading `system` message returned by the API is read back into `system_prompt` unless `system_prompt` is unset and `messages` starts with a `system` message.",
 						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"role": schema.StringAttribute{
+									MarkdownDescription: "Role of the message author: system, user, assistant, tool or function.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf(modelMessageRoles...),
+									},
+								},
+								"content": schema.StringAttribute{
+									MarkdownDescription: "Content of the message.",
+									Required:            true,
+								},
+							},
+						},
 					},
 					"provider": schema.StringAttribute{
 						MarkdownDescription: "Provider for the assistant model: openai, azure-openai, together-ai, anyscale, openrouter, perplexity-ai, deepinfra, custom-llm, runpod, groq, vapi, anthropic, google",
@@ -274,6 +505,66 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 						Optional:            true,
 						Computed:            true,
 					},
+					"fallback_models": schema.ListNestedAttribute{
+						MarkdownDescription: "Models to fall back to, in order, when the primary model fails.",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"provider": schema.StringAttribute{
+									MarkdownDescription: "Provider for the fallback model. `custom-llm` is not supported because a fallback carries no server URL.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf(fallbackModelProviders...),
+									},
+								},
+								"model": schema.StringAttribute{
+									MarkdownDescription: "The fallback model type.",
+									Required:            true,
+								},
+							},
+						},
+					},
+					"tools": schema.ListNestedAttribute{
+						MarkdownDescription: "Tools defined inline on the model, for one-off tools that do not need a `vapi_tool_function` resource.",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Type of the tool: transferCall, endCall, function or dtmf.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf(modelToolTypes...),
+									},
+								},
+								"async": schema.BoolAttribute{
+									MarkdownDescription: "Indicates whether the assistant continues the conversation without waiting for the tool result.",
+									Optional:            true,
+								},
+								"function": schema.SingleNestedAttribute{
+									MarkdownDescription: "Function definition the model calls.",
+									Optional:            true,
+									Attributes: map[string]schema.Attribute{
+										"name": schema.StringAttribute{
+											MarkdownDescription: "The name of the function.",
+											Required:            true,
+										},
+										"description": schema.StringAttribute{
+											MarkdownDescription: "The description of the function.",
+											Optional:            true,
+										},
+										"parameters": functionParametersAttribute(),
+									},
+								},
+								"messages": toolMessagesAttribute(),
+								"destinations": schema.ListNestedAttribute{
+									MarkdownDescription: "Destinations a transferCall tool can forward the call to.",
+									Optional:            true,
+									NestedObject:        destinationNestedObject(),
+								},
+								"server": serverAttribute("Server that handles the tool calls. Defaults to the assistant server."),
+							},
+						},
+					},
 					"knowledge_base": schema.SingleNestedAttribute{
 						MarkdownDescription: "Knowledge base configuration for the assistant model.",
 						Optional:            true,
@@ -321,6 +612,119 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 						MarkdownDescription: "Boost factor for similarity in voice.",
 						Optional:            true,
 					},
+					"speed": schema.Float64Attribute{
+						MarkdownDescription: "Speech rate multiplier. Supported by `11labs`, `azure`, `lmnt`, `minimax`, `openai`, `playht`, `rime-ai` and `vapi`.",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(0.25, 4),
+							supportedByProviders(voiceSpeedProviders...),
+						},
+					},
+					"style": schema.Float64Attribute{
+						MarkdownDescription: "Style exaggeration between 0 and 1. Supported by `11labs`.",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 1),
+							supportedByProviders("11labs"),
+						},
+					},
+					"emotion": schema.StringAttribute{
+						MarkdownDescription: "Emotion the voice speaks with. Supported by `minimax` and `playht`.",
+						Optional:            true,
+						Validators: []validator.String{
+							supportedByProviders("minimax", "playht"),
+						},
+					},
+					"language": schema.StringAttribute{
+						MarkdownDescription: "Language of the voice. Supported by `11labs`, `cartesia`, `lmnt`, `minimax` and `playht`.",
+						Optional:            true,
+						Validators: []validator.String{
+							supportedByProviders(voiceLanguageProviders...),
+						},
+					},
+					"optimize_streaming_latency": schema.Int64Attribute{
+						MarkdownDescription: "Latency optimization level between 0 and 4. Supported by `11labs`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 4),
+							supportedByProviders("11labs"),
+						},
+					},
+					"input_preprocessing_enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether text is preprocessed before synthesis. Supported by `rime-ai`.",
+						Optional:            true,
+						Validators: []validator.Bool{
+							supportedByProviders("rime-ai"),
+						},
+					},
+					"chunk_plan": schema.SingleNestedAttribute{
+						MarkdownDescription: "How model output is split into chunks before synthesis.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"enabled": schema.BoolAttribute{
+								MarkdownDescription: "Whether output is chunked. Disable only when the model already emits complete sentences.",
+								Optional:            true,
+							},
+							"min_characters": schema.Int64Attribute{
+								MarkdownDescription: "Minimum number of characters in a chunk, between 1 and 80.",
+								Optional:            true,
+								Validators: []validator.Int64{
+									int64validator.Between(1, 80),
+								},
+							},
+							"punctuation_boundaries": schema.ListAttribute{
+								MarkdownDescription: "Punctuation marks that end a chunk.",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+						},
+					},
+					"fallback_plan": schema.SingleNestedAttribute{
+						MarkdownDescription: "Voices to fall back to when the primary voice provider fails.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"voices": schema.ListNestedAttribute{
+								MarkdownDescription: "Fallback voices, tried in order.",
+								Required:            true,
+								Validators: []validator.List{
+									listvalidator.SizeAtLeast(1),
+								},
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"provider": schema.StringAttribute{
+											MarkdownDescription: "Provider for the voice model.",
+											Required:            true,
+											Validators: []validator.String{
+												stringvalidator.OneOf(voiceProviders...),
+											},
+										},
+										"voice_id": schema.StringAttribute{
+											MarkdownDescription: "ID of the voice model.",
+											Required:            true,
+										},
+										"model": schema.StringAttribute{
+											MarkdownDescription: "Model for the voice model.",
+											Optional:            true,
+										},
+										"stability": schema.Float64Attribute{
+											MarkdownDescription: "Stability of the voice output. Supported by `11labs`.",
+											Optional:            true,
+											Validators: []validator.Float64{
+												supportedByProviders("11labs"),
+											},
+										},
+										"similarity_boost": schema.Float64Attribute{
+											MarkdownDescription: "Boost factor for similarity in voice. Supported by `11labs`.",
+											Optional:            true,
+											Validators: []validator.Float64{
+												supportedByProviders("11labs"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 			"start_speaking_plan": schema.SingleNestedAttribute{
@@ -388,8 +792,12 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 						Optional:            true,
 					},
 					"structured_data_schema": schema.SingleNestedAttribute{
-						MarkdownDescription: "Schema for structured data.",
+						MarkdownDescription: "Schema for structured data. Only supports flat properties; use `structured_data_schema_json` for nested schemas.",
+						DeprecationMessage:  "Use structured_data_schema_json instead.",
 						Optional:            true,
+						Validators: []validator.Object{
+							objectvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("structured_data_schema_json")),
+						},
 						Attributes: map[string]schema.Attribute{
 							"type": schema.StringAttribute{
 								MarkdownDescription: "Type of structured data.",
@@ -413,6 +821,11 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 							},
 						},
 					},
+					"structured_data_schema_json": schema.StringAttribute{
+						MarkdownDescription: "JSON schema of the structured data, usually written with `jsonencode()`. Supports nested objects, arrays of objects, `enum` and `required`. Formatting and key order differences are not reported as changes.",
+						Optional:            true,
+						CustomType:          jsontypes.NormalizedType{},
+					},
 					"success_evaluation_prompt": schema.StringAttribute{
 						MarkdownDescription: "Prompt for success evaluation.",
 						Optional:            true,
@@ -421,6 +834,23 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 						MarkdownDescription: "Rubric for evaluating success.",
 						Optional:            true,
 					},
+					"summary_plan": analysisSubPlanAttribute("Generation of the call summary. `messages` replace `summary_prompt`.", nil),
+					"structured_data_plan": analysisSubPlanAttribute("Extraction of structured data from the call. `messages` replace `structured_data_prompt`.", map[string]schema.Attribute{
+						"schema_json": schema.StringAttribute{
+							MarkdownDescription: "JSON schema of the extracted data, usually written with `jsonencode()`. Formatting and key order differences are not reported as changes.",
+							Optional:            true,
+							CustomType:          jsontypes.NormalizedType{},
+						},
+					}),
+					"success_evaluation_plan": analysisSubPlanAttribute("Evaluation of whether the call succeeded. `messages` replace `success_evaluation_prompt`.", map[string]schema.Attribute{
+						"rubric": schema.StringAttribute{
+							MarkdownDescription: "Rubric used for the evaluation: " + strings.Join(successEvaluationRubrics, ", ") + ".",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(successEvaluationRubrics...),
+							},
+						},
+					}),
 				},
 			},
 
@@ -441,8 +871,202 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"recording_format": schema.StringAttribute{
-						MarkdownDescription: "Recording format wav or mp3.",
+						MarkdownDescription: "Recording format wav or mp3. Defaults to mp3.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"recording_path": schema.StringAttribute{
+						MarkdownDescription: "Bucket path where recordings are uploaded, for example `s3://bucket/recordings`. Requires a storage credential.",
+						Optional:            true,
+					},
+					"video_recording_enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether video is recorded on web calls.",
+						Optional:            true,
+					},
+					"pcap_enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether a packet capture of the SIP call is stored.",
+						Optional:            true,
+					},
+					"transcript_plan": schema.SingleNestedAttribute{
+						MarkdownDescription: "Stored call transcript.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"enabled": schema.BoolAttribute{
+								MarkdownDescription: "Whether the transcript is stored.",
+								Optional:            true,
+							},
+							"assistant_name": schema.StringAttribute{
+								MarkdownDescription: "Name used for the assistant in the transcript.",
+								Optional:            true,
+							},
+							"user_name": schema.StringAttribute{
+								MarkdownDescription: "Name used for the customer in the transcript.",
+								Optional:            true,
+							},
+						},
+					},
+				},
+			},
+
+			"voicemail_detection": schema.SingleNestedAttribute{
+				MarkdownDescription: "Voicemail detection for outbound calls.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"provider": schema.StringAttribute{
+						MarkdownDescription: "Provider used to detect voicemail: `twilio`, `google`, `openai` or `vapi`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(voicemailDetectionProviders...),
+						},
+					},
+					"machine_detection_timeout": schema.Int64Attribute{
+						MarkdownDescription: "Seconds Twilio spends detecting a machine, between 3 and 59. Supported by `twilio`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(3, 59),
+							supportedByProviders("twilio"),
+						},
+					},
+					"backoff_plan": schema.SingleNestedAttribute{
+						MarkdownDescription: "When and how often detection is retried. Supported by `google`, `openai` and `vapi`.",
+						Optional:            true,
+						Validators: []validator.Object{
+							supportedByProviders("google", "openai", "vapi"),
+						},
+						Attributes: map[string]schema.Attribute{
+							"start_at_seconds": schema.Float64Attribute{
+								MarkdownDescription: "Seconds into the call before the first attempt, between 0 and 60.",
+								Optional:            true,
+								Validators: []validator.Float64{
+									float64validator.Between(0, 60),
+								},
+							},
+							"frequency_seconds": schema.Float64Attribute{
+								MarkdownDescription: "Seconds between attempts, between 2.5 and 10.",
+								Optional:            true,
+								Validators: []validator.Float64{
+									float64validator.Between(2.5, 10),
+								},
+							},
+							"max_retries": schema.Int64Attribute{
+								MarkdownDescription: "Maximum number of attempts, between 1 and 10.",
+								Optional:            true,
+								Validators: []validator.Int64{
+									int64validator.Between(1, 10),
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"keypad_input_plan": schema.SingleNestedAttribute{
+				MarkdownDescription: "How DTMF keypad input from the caller is collected.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether keypad input is collected.",
+						Optional:            true,
+					},
+					"timeout_seconds": schema.Float64Attribute{
+						MarkdownDescription: "Seconds to wait after the last key press before the input is sent, between 0 and 10.",
+						Optional:            true,
+						Validators: []validator.Float64{
+							float64validator.Between(0, 10),
+						},
+					},
+					"delimiters": schema.ListAttribute{
+						MarkdownDescription: "Keys that end the input immediately: `#`, `*` or an empty string.",
 						Optional:            true,
+						ElementType:         types.StringType,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.OneOf(keypadDelimiters...)),
+						},
+					},
+				},
+			},
+
+			"hooks": schema.ListNestedAttribute{
+				MarkdownDescription: "Actions run when call events occur.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"on": schema.StringAttribute{
+							MarkdownDescription: "Event that triggers the hook: `call.ending` or `customer.speech.timeout`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(hookEvents...),
+							},
+						},
+						"do": schema.ListNestedAttribute{
+							MarkdownDescription: "Actions run in order when the hook fires.",
+							Required:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "Action type: `say` or `transfer`.",
+										Required:            true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(hookActionTypes...),
+										},
+									},
+									"exact": schema.StringAttribute{
+										MarkdownDescription: "Text spoken verbatim by a `say` action.",
+										Optional:            true,
+										Validators: []validator.String{
+											supportedByTypes("say"),
+											stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("prompt")),
+										},
+									},
+									"prompt": schema.StringAttribute{
+										MarkdownDescription: "Prompt the model uses to generate what a `say` action speaks.",
+										Optional:            true,
+										Validators: []validator.String{
+											supportedByTypes("say"),
+										},
+									},
+									"destination": schema.SingleNestedAttribute{
+										MarkdownDescription: "Destination of a `transfer` action.",
+										Optional:            true,
+										Attributes:          hookDestinationAttributes(),
+										Validators: []validator.Object{
+											supportedByTypes("transfer"),
+											destinationAddressValidator{required: map[string]string{"number": "number", "sip": "sip_uri"}},
+										},
+									},
+								},
+							},
+						},
+						"options": schema.SingleNestedAttribute{
+							MarkdownDescription: "When a `customer.speech.timeout` hook fires.",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"timeout_seconds": schema.Float64Attribute{
+									MarkdownDescription: "Seconds of customer silence before the hook fires, between 1 and 1000.",
+									Optional:            true,
+									Validators: []validator.Float64{
+										float64validator.Between(1, 1000),
+									},
+								},
+								"trigger_max_count": schema.Int64Attribute{
+									MarkdownDescription: "Maximum number of times the hook fires per call, between 1 and 10.",
+									Optional:            true,
+									Validators: []validator.Int64{
+										int64validator.Between(1, 10),
+									},
+								},
+								"trigger_reset_mode": schema.StringAttribute{
+									MarkdownDescription: "When the trigger count resets: `never` or `onUserSpeech`.",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf("never", "onUserSpeech"),
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -469,24 +1093,20 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 			},
 
 			"response_delay_seconds": schema.Float64Attribute{
-				MarkdownDescription: "Response Delay Seconds",
+				MarkdownDescription: "Seconds the assistant waits after the customer stops speaking before it responds.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"dial_keypad_function_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Dial Keypad Function enabled",
+				MarkdownDescription: "Lets the assistant press keypad digits during the call.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"num_words_to_interrupt_assistant": schema.Int64Attribute{
-				MarkdownDescription: "Num words to interrupt assistant",
+				MarkdownDescription: "Number of words the customer must say before the assistant stops speaking.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"interruptions_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Interruptions enabled",
+				MarkdownDescription: "Whether the customer can interrupt the assistant while it is speaking.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"keywords": schema.ListAttribute{
 				ElementType:         types.StringType,
@@ -495,19 +1115,16 @@ func (r *VAPIAssistantResource) Schema(ctx context.Context, req resource.SchemaR
 				Computed:            true,
 			},
 			"fillers_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Fillers enabled",
+				MarkdownDescription: "Whether the assistant uses filler words such as \"um\" to sound more natural.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"language": schema.StringAttribute{
-				MarkdownDescription: "Language",
+				MarkdownDescription: "Language the assistant speaks, for example `en`.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"live_transcripts_enabled": schema.BoolAttribute{
-				MarkdownDescription: "Parent ID.",
+				MarkdownDescription: "Whether transcripts are sent to the server while the call is in progress.",
 				Optional:            true,
-				Computed:            true,
 			},
 			"parent_id": schema.StringAttribute{
 				MarkdownDescription: "Parent ID.",
@@ -543,26 +1160,15 @@ func (r *VAPIAssistantResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	requestBody := mapVAPIAssistantRequest(&data)
+	requestBody := mapVAPIAssistantRequest(&data, nil)
 
-	response, responseCode, err := r.client.CreateAssistant(requestBody)
+	assistantResponse, err := r.client.CreateAssistant(ctx, requestBody)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create assistant: %s", err))
-		return
-	}
-
-	var assistantResponse vapi.Assistant
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &assistantResponse); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create assistant", err)
 		return
 	}
 
-	mapResponseObject(&data, &assistantResponse)
+	mapResponseObject(&data, assistantResponse)
 	tflog.Trace(ctx, "created an assistant resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -576,29 +1182,17 @@ func (r *VAPIAssistantResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	response, responseCode, err := r.client.GetAssistant(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read assistant: %s", err))
-		return
-	}
-
-	if responseCode == 404 {
+	assistantResponse, err := r.client.GetAssistant(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	var assistantResponse vapi.Assistant
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &assistantResponse); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read assistant", err)
 		return
 	}
 
-	mapResponseObject(&data, &assistantResponse)
+	mapResponseObject(&data, assistantResponse)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -618,26 +1212,15 @@ func (r *VAPIAssistantResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	requestBody := mapVAPIAssistantRequest(&data)
+	requestBody := mapVAPIAssistantRequest(&data, &state)
 
-	response, responseCode, err := r.client.UpdateAssistant(state.ID.ValueString(), requestBody)
+	assistantResponse, err := r.client.UpdateAssistant(ctx, state.ID.ValueString(), requestBody)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update assistant: %s", err))
-		return
-	}
-
-	var assistantResponse vapi.Assistant
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &assistantResponse); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update assistant", err)
 		return
 	}
 
-	mapResponseObject(&data, &assistantResponse)
+	mapResponseObject(&data, assistantResponse)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -651,31 +1234,45 @@ func (r *VAPIAssistantResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	_, _, err := r.client.DeleteAssistant(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete assistant: %s", err))
+	err := r.client.DeleteAssistant(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete assistant", err)
 		return
 	}
 
 	tflog.Trace(ctx, "deleted an assistant resource")
 }
 
+// UpgradeState moves the deprecated server_url and server_url_secret of
+// version 0 state into the server block.
+func (r *VAPIAssistantResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: serverBlockUpgrader("server_url", "server_url_secret"),
+	}
+}
+
+// ImportState imports an existing assistant by ID. The following Read
+// populates the rest of the state from the API.
+func (r *VAPIAssistantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi.Assistant) {
 	// Basic fields
 	data.ID = types.StringValue(assistantResponse.ID)
 	data.OrgID = types.StringValue(assistantResponse.OrgID)
 	data.Name = types.StringValue(assistantResponse.Name)
-	data.FirstMessageMode = types.StringValue(assistantResponse.FirstMessageMode)
-	data.HipaaEnabled = types.BoolValue(assistantResponse.HipaaEnabled)
-	data.BackgroundSound = types.StringValue(assistantResponse.BackgroundSound)
-	data.BackgroundDenoising = types.BoolValue(assistantResponse.BackgroundDenoising)
-	data.ModelOutputEnabled = types.BoolValue(assistantResponse.ModelOutputEnabled)
-	data.Language = types.StringValue(assistantResponse.Language)
-	data.ForwardingPhoneNumber = types.StringValue(assistantResponse.ForwardingPhoneNumber)
-	data.InterruptionsEnabled = types.BoolValue(assistantResponse.InterruptionsEnabled)
-	data.EndCallFunctionEnabled = types.BoolValue(assistantResponse.EndCallFunctionEnabled)
-	data.DialKeypadFunctionEnabled = types.BoolValue(assistantResponse.DialKeypadFunctionEnabled)
-	data.FillersEnabled = types.BoolValue(assistantResponse.FillersEnabled)
+	data.FirstMessageMode = types.StringPointerValue(assistantResponse.FirstMessageMode)
+	data.HipaaEnabled = types.BoolPointerValue(assistantResponse.HipaaEnabled)
+	data.BackgroundSound = types.StringPointerValue(assistantResponse.BackgroundSound)
+	data.BackgroundDenoising = types.BoolPointerValue(assistantResponse.BackgroundDenoising)
+	data.ModelOutputEnabled = types.BoolPointerValue(assistantResponse.ModelOutputEnabled)
+	data.Language = types.StringPointerValue(assistantResponse.Language)
+	data.ForwardingPhoneNumber = types.StringPointerValue(assistantResponse.ForwardingPhoneNumber)
+	data.InterruptionsEnabled = types.BoolPointerValue(assistantResponse.InterruptionsEnabled)
+	data.EndCallFunctionEnabled = types.BoolPointerValue(assistantResponse.EndCallFunctionEnabled)
+	data.DialKeypadFunctionEnabled = types.BoolPointerValue(assistantResponse.DialKeypadFunctionEnabled)
+	data.FillersEnabled = types.BoolPointerValue(assistantResponse.FillersEnabled)
 	data.SilenceTimeoutSeconds = types.Float64PointerValue(assistantResponse.SilenceTimeoutSeconds)
 	data.ResponseDelaySeconds = types.Float64PointerValue(assistantResponse.ResponseDelaySeconds)
 	data.NumWordsToInterruptAssistant = types.Int64PointerValue(assistantResponse.NumWordsToInterruptAssistant)
@@ -689,8 +1286,17 @@ func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi
 			Model:           types.StringValue(assistantResponse.Voice.Model),
 			Provider:        types.StringValue(assistantResponse.Voice.Provider),
 			VoiceID:         types.StringValue(assistantResponse.Voice.VoiceID),
-			Stability:       types.Float64Value(assistantResponse.Voice.Stability),
-			SimilarityBoost: types.Float64Value(assistantResponse.Voice.SimilarityBoost),
+			Stability:       types.Float64PointerValue(assistantResponse.Voice.Stability),
+			SimilarityBoost: types.Float64PointerValue(assistantResponse.Voice.SimilarityBoost),
+			FallbackPlan:    flattenVoiceFallbackPlan(assistantResponse.Voice.FallbackPlan),
+
+			Speed:                     types.Float64PointerValue(assistantResponse.Voice.Speed),
+			Style:                     types.Float64PointerValue(assistantResponse.Voice.Style),
+			Emotion:                   types.StringPointerValue(assistantResponse.Voice.Emotion),
+			Language:                  types.StringPointerValue(assistantResponse.Voice.Language),
+			OptimizeStreamingLatency:  types.Int64PointerValue(assistantResponse.Voice.OptimizeStreamingLatency),
+			InputPreprocessingEnabled: types.BoolPointerValue(assistantResponse.Voice.InputPreprocessingEnabled),
+			ChunkPlan:                 flattenChunkPlan(assistantResponse.Voice.ChunkPlan),
 		}
 	} else {
 		data.Voice = nil
@@ -698,17 +1304,25 @@ func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi
 
 	// Handle optional Model struct
 	if assistantResponse.Model != nil {
+		systemPrompt, messages := splitModelMessages(data.Model, assistantResponse.Model)
+		var priorTools []ModelToolResourceModel
+		if data.Model != nil {
+			priorTools = data.Model.Tools
+		}
 		data.Model = &ModelResourceModel{
-			Model:        types.StringValue(assistantResponse.Model.Model),
-			SystemPrompt: types.StringValue(assistantResponse.Model.SystemPrompt),
-			Provider:     types.StringValue(assistantResponse.Model.Provider),
-			MaxTokens:    types.Int64Value(assistantResponse.Model.MaxTokens),
-			Temperature:  types.Float64Value(assistantResponse.Model.Temperature),
-			ToolIDs:      ListValueFromStrings(assistantResponse.Model.ToolIDs),
+			SystemPrompt:   systemPrompt,
+			Messages:       messages,
+			Model:          types.StringValue(assistantResponse.Model.Model),
+			Provider:       types.StringValue(assistantResponse.Model.Provider),
+			MaxTokens:      types.Int64PointerValue(assistantResponse.Model.MaxTokens),
+			Temperature:    types.Float64PointerValue(assistantResponse.Model.Temperature),
+			ToolIDs:        ListValueFromStrings(assistantResponse.Model.ToolIDs),
+			Tools:          flattenModelTools(priorTools, assistantResponse.Model.Tools),
+			FallbackModels: flattenFallbackModels(assistantResponse.Model.FallbackModels),
 			KnowledgeBase: func() *KnowledgeBaseResourceModel {
 				if assistantResponse.Model.KnowledgeBase != nil {
 					return &KnowledgeBaseResourceModel{
-						TopK:     types.Int64Value(assistantResponse.Model.KnowledgeBase.TopK),
+						TopK:     types.Int64PointerValue(assistantResponse.Model.KnowledgeBase.TopK),
 						FileIDs:  ListValueFromStrings(assistantResponse.Model.KnowledgeBase.FileIDs),
 						Provider: types.StringValue(assistantResponse.Model.KnowledgeBase.Provider),
 					}
@@ -720,30 +1334,36 @@ func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi
 		data.Model = nil
 	}
 
-	data.RecordingEnabled = types.BoolValue(assistantResponse.RecordingEnabled)
-	data.FirstMessage = types.StringValue(assistantResponse.FirstMessage)
-	data.VoicemailMessage = types.StringValue(assistantResponse.VoicemailMessage)
-	data.EndCallFunctionEnabled = types.BoolValue(assistantResponse.EndCallFunctionEnabled)
+	data.RecordingEnabled = types.BoolPointerValue(assistantResponse.RecordingEnabled)
+	data.FirstMessage = types.StringPointerValue(assistantResponse.FirstMessage)
+	data.VoicemailMessage = types.StringPointerValue(assistantResponse.VoicemailMessage)
+	data.EndCallMessage = types.StringPointerValue(assistantResponse.EndCallMessage)
 
 	// Handle optional Transcriber struct
 	if assistantResponse.Transcriber != nil {
 		data.Transcriber = &TranscriberResourceModel{
-			Provider: types.StringValue(assistantResponse.Transcriber.Provider),
-			Model:    types.StringValue(assistantResponse.Transcriber.Model),
-			Language: types.StringValue(assistantResponse.Transcriber.Language),
+			Provider:     types.StringValue(assistantResponse.Transcriber.Provider),
+			Model:        types.StringPointerValue(assistantResponse.Transcriber.Model),
+			Language:     types.StringPointerValue(assistantResponse.Transcriber.Language),
+			FallbackPlan: flattenTranscriberFallbackPlan(assistantResponse.Transcriber.FallbackPlan),
 		}
 	} else {
 		data.Transcriber = nil
 	}
 
-	//data.ServerURL = types.StringValue(assistantResponse.ServerURL)
-	//data.ServerURLSecret = types.StringValue(assistantResponse.ServerURLSecret)
+	flattenServer(assistantResponse.Server, &data.Server, &data.ServerURL, &data.ServerURLSecret)
 
 	data.ClientMessages = ListValueFromStrings(assistantResponse.ClientMessages)
 	data.ServerMessages = ListValueFromStrings(assistantResponse.ServerMessages)
-	data.EndCallPhrases = ListValueFromStrings(assistantResponse.EndCallPhrases)
+	// end_call_phrases is not computed, so a list the API reports as empty
+	// stays null when it was removed from configuration.
+	if len(assistantResponse.EndCallPhrases) > 0 || !data.EndCallPhrases.IsNull() {
+		data.EndCallPhrases = ListValueFromStrings(assistantResponse.EndCallPhrases)
+	} else {
+		data.EndCallPhrases = types.ListNull(types.StringType)
+	}
 
-	data.MaxDurationSeconds = types.Int64Value(assistantResponse.MaxDurationSeconds)
+	data.MaxDurationSeconds = types.Int64PointerValue(assistantResponse.MaxDurationSeconds)
 
 	if assistantResponse.MessagePlan != nil {
 		data.MessagePlan = &MessagePlanResourceModel{
@@ -755,13 +1375,17 @@ func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi
 
 	// Handle optional AnalysisPlan struct
 	if assistantResponse.AnalysisPlan != nil {
+		prior := data.AnalysisPlan
 		data.AnalysisPlan = &AnalysisPlanResourceModel{
-			SummaryPrompt:           types.StringValue(assistantResponse.AnalysisPlan.SummaryPrompt),
-			StructuredDataPrompt:    types.StringValue(assistantResponse.AnalysisPlan.StructuredDataPrompt),
-			StructuredDataSchema:    mapStructuredDataSchemaRequestToResourceModel(assistantResponse.AnalysisPlan.StructuredDataSchema),
-			SuccessEvaluationPrompt: types.StringValue(assistantResponse.AnalysisPlan.SuccessEvaluationPrompt),
-			SuccessEvaluationRubric: types.StringValue(assistantResponse.AnalysisPlan.SuccessEvaluationRubric),
+			SummaryPrompt:           types.StringPointerValue(assistantResponse.AnalysisPlan.SummaryPrompt),
+			StructuredDataPrompt:    types.StringPointerValue(assistantResponse.AnalysisPlan.StructuredDataPrompt),
+			SuccessEvaluationPrompt: types.StringPointerValue(assistantResponse.AnalysisPlan.SuccessEvaluationPrompt),
+			SuccessEvaluationRubric: types.StringPointerValue(assistantResponse.AnalysisPlan.SuccessEvaluationRubric),
 		}
+		data.AnalysisPlan.StructuredDataSchema, data.AnalysisPlan.StructuredDataSchemaJSON = flattenStructuredDataSchema(assistantResponse.AnalysisPlan.StructuredDataSchema, prior)
+		data.AnalysisPlan.SummaryPlan = flattenSummaryPlan(assistantResponse.AnalysisPlan.SummaryPlan)
+		data.AnalysisPlan.StructuredDataPlan = flattenStructuredDataPlan(assistantResponse.AnalysisPlan.StructuredDataPlan)
+		data.AnalysisPlan.SuccessEvaluationPlan = flattenSuccessEvaluationPlan(assistantResponse.AnalysisPlan.SuccessEvaluationPlan)
 	} else {
 		data.AnalysisPlan = nil
 	}
@@ -769,14 +1393,14 @@ func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi
 	// Handle optional StartSpeakingPlan struct
 	if assistantResponse.StartSpeakingPlan != nil {
 		data.StartSpeakingPlan = &StartSpeakingPlanResourceModel{
-			WaitSeconds:             types.Float64Value(assistantResponse.StartSpeakingPlan.WaitSeconds),
-			SmartEndpointingEnabled: types.BoolValue(assistantResponse.StartSpeakingPlan.SmartEndpointingEnabled),
+			WaitSeconds:             types.Float64PointerValue(assistantResponse.StartSpeakingPlan.WaitSeconds),
+			SmartEndpointingEnabled: types.BoolPointerValue(assistantResponse.StartSpeakingPlan.SmartEndpointingEnabled),
 			TranscriptionEndpointingPlan: func() *TranscriptionEndpointingPlanResourceModel {
 				if assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan != nil {
 					return &TranscriptionEndpointingPlanResourceModel{
-						OnPunctuationSeconds:   types.Float64Value(assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan.OnPunctuationSeconds),
-						OnNoPunctuationSeconds: types.Float64Value(assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds),
-						OnNumberSeconds:        types.Float64Value(assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNumberSeconds),
+						OnPunctuationSeconds:   types.Float64PointerValue(assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan.OnPunctuationSeconds),
+						OnNoPunctuationSeconds: types.Float64PointerValue(assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds),
+						OnNumberSeconds:        types.Float64PointerValue(assistantResponse.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNumberSeconds),
 					}
 				}
 				return nil
@@ -789,71 +1413,106 @@ func mapResponseObject(data *VAPIAssistantResourceModel, assistantResponse *vapi
 	// Handle optional StopSpeakingPlan struct
 	if assistantResponse.StopSpeakingPlan != nil {
 		data.StopSpeakingPlan = &StopSpeakingPlanResourceModel{
-			NumWords:       types.Float64Value(assistantResponse.StopSpeakingPlan.NumWords),
-			VoiceSeconds:   types.Float64Value(assistantResponse.StopSpeakingPlan.VoiceSeconds),
-			BackoffSeconds: types.Float64Value(assistantResponse.StopSpeakingPlan.BackoffSeconds),
+			NumWords:       types.Float64PointerValue(assistantResponse.StopSpeakingPlan.NumWords),
+			VoiceSeconds:   types.Float64PointerValue(assistantResponse.StopSpeakingPlan.VoiceSeconds),
+			BackoffSeconds: types.Float64PointerValue(assistantResponse.StopSpeakingPlan.BackoffSeconds),
 		}
 	} else {
 		data.StopSpeakingPlan = nil
 	}
+
+	// Handle optional ArtifactPlan struct
+	if assistantResponse.ArtifactPlan != nil {
+		data.ArtifactPlan = &ArtifactPlanResourceModel{
+			RecordingFormat:       types.StringValue(assistantResponse.ArtifactPlan.RecordingFormat),
+			RecordingPath:         types.StringPointerValue(assistantResponse.ArtifactPlan.RecordingPath),
+			VideoRecordingEnabled: types.BoolPointerValue(assistantResponse.ArtifactPlan.VideoRecordingEnabled),
+			PcapEnabled:           types.BoolPointerValue(assistantResponse.ArtifactPlan.PcapEnabled),
+			TranscriptPlan:        flattenTranscriptPlan(assistantResponse.ArtifactPlan.TranscriptPlan),
+		}
+	} else {
+		data.ArtifactPlan = nil
+	}
+
+	data.VoicemailDetection = flattenVoicemailDetection(assistantResponse.VoicemailDetection)
+	data.KeypadInputPlan = flattenKeypadInputPlan(assistantResponse.KeypadInputPlan)
+	data.Hooks = flattenHooks(assistantResponse.Hooks)
 }
 
-func mapVAPIAssistantRequest(data *VAPIAssistantResourceModel) vapi.CreateAssistantRequest {
+// mapVAPIAssistantRequest builds the API payload from the planned model.
+// prior is the current state on update and nil on create; attributes that
+// were set in prior but are null in the plan are sent as an explicit null.
+func mapVAPIAssistantRequest(data, prior *VAPIAssistantResourceModel) vapi.CreateAssistantRequest {
+	if prior == nil {
+		prior = &VAPIAssistantResourceModel{}
+	}
+
 	return vapi.CreateAssistantRequest{
 		Name:                         data.Name.ValueString(),
-		FirstMessageMode:             data.FirstMessageMode.ValueString(),
-		HipaaEnabled:                 data.HipaaEnabled.ValueBool(),
-		BackgroundSound:              data.BackgroundSound.ValueString(),
-		BackgroundDenoising:          data.BackgroundDenoising.ValueBool(),
-		ModelOutputEnabled:           data.ModelOutputEnabled.ValueBool(),
-		Language:                     data.Language.ValueString(),
-		ForwardingPhoneNumber:        data.ForwardingPhoneNumber.ValueString(),
-		InterruptionsEnabled:         data.InterruptionsEnabled.ValueBool(),
-		EndCallFunctionEnabled:       data.EndCallFunctionEnabled.ValueBool(),
-		DialKeypadFunctionEnabled:    data.DialKeypadFunctionEnabled.ValueBool(),
-		FillersEnabled:               data.FillersEnabled.ValueBool(),
-		SilenceTimeoutSeconds:        data.SilenceTimeoutSeconds.ValueFloat64Pointer(),
-		ResponseDelaySeconds:         data.ResponseDelaySeconds.ValueFloat64Pointer(),
-		NumWordsToInterruptAssistant: data.NumWordsToInterruptAssistant.ValueInt64Pointer(),
-		LiveTranscriptsEnabled:       data.LiveTranscriptsEnabled.ValueBoolPointer(),
-		Keywords:                     ElementsAsString(data.Keywords),
-		ServerMessages:               ElementsAsString(data.ServerMessages),
-
-		MessagePlan: func() *vapi.MessagePlan {
+		FirstMessageMode:             nullableValue(data.FirstMessageMode, prior.FirstMessageMode, data.FirstMessageMode.ValueString),
+		HipaaEnabled:                 nullableValue(data.HipaaEnabled, prior.HipaaEnabled, data.HipaaEnabled.ValueBool),
+		BackgroundSound:              nullableValue(data.BackgroundSound, prior.BackgroundSound, data.BackgroundSound.ValueString),
+		BackgroundDenoising:          nullableValue(data.BackgroundDenoising, prior.BackgroundDenoising, data.BackgroundDenoising.ValueBool),
+		ModelOutputEnabled:           nullableValue(data.ModelOutputEnabled, prior.ModelOutputEnabled, data.ModelOutputEnabled.ValueBool),
+		Language:                     nullableValue(data.Language, prior.Language, data.Language.ValueString),
+		ForwardingPhoneNumber:        nullableValue(data.ForwardingPhoneNumber, prior.ForwardingPhoneNumber, data.ForwardingPhoneNumber.ValueString),
+		InterruptionsEnabled:         nullableValue(data.InterruptionsEnabled, prior.InterruptionsEnabled, data.InterruptionsEnabled.ValueBool),
+		EndCallFunctionEnabled:       nullableValue(data.EndCallFunctionEnabled, prior.EndCallFunctionEnabled, data.EndCallFunctionEnabled.ValueBool),
+		DialKeypadFunctionEnabled:    nullableValue(data.DialKeypadFunctionEnabled, prior.DialKeypadFunctionEnabled, data.DialKeypadFunctionEnabled.ValueBool),
+		FillersEnabled:               nullableValue(data.FillersEnabled, prior.FillersEnabled, data.FillersEnabled.ValueBool),
+		SilenceTimeoutSeconds:        nullableValue(data.SilenceTimeoutSeconds, prior.SilenceTimeoutSeconds, data.SilenceTimeoutSeconds.ValueFloat64),
+		ResponseDelaySeconds:         nullableValue(data.ResponseDelaySeconds, prior.ResponseDelaySeconds, data.ResponseDelaySeconds.ValueFloat64),
+		NumWordsToInterruptAssistant: nullableValue(data.NumWordsToInterruptAssistant, prior.NumWordsToInterruptAssistant, data.NumWordsToInterruptAssistant.ValueInt64),
+		LiveTranscriptsEnabled:       nullableValue(data.LiveTranscriptsEnabled, prior.LiveTranscriptsEnabled, data.LiveTranscriptsEnabled.ValueBool),
+		Keywords:                     computedNullableValue(data.Keywords, prior.Keywords, func() []string { return ElementsAsString(data.Keywords) }),
+		ServerMessages:               computedNullableValue(data.ServerMessages, prior.ServerMessages, func() []string { return ElementsAsString(data.ServerMessages) }),
+
+		MessagePlan: objectValue(func() *vapi.MessagePlan {
 			if data.MessagePlan != nil {
 				return &vapi.MessagePlan{
 					IdleMessages: ElementsAsString(data.MessagePlan.IdleMessages),
 				}
 			}
 			return nil
-		}(),
+		}(), prior.MessagePlan != nil),
 
-		Voice: func() *vapi.Voice {
+		Voice: objectValue(func() *vapi.Voice {
 			if data.Voice != nil {
 				return &vapi.Voice{
 					Model:           data.Voice.Model.ValueString(),
 					VoiceID:         data.Voice.VoiceID.ValueString(),
 					Provider:        data.Voice.Provider.ValueString(),
-					Stability:       data.Voice.Stability.ValueFloat64(),
-					SimilarityBoost: data.Voice.SimilarityBoost.ValueFloat64(),
+					Stability:       valuePointer(data.Voice.Stability, data.Voice.Stability.ValueFloat64),
+					SimilarityBoost: valuePointer(data.Voice.SimilarityBoost, data.Voice.SimilarityBoost.ValueFloat64),
+					FallbackPlan:    buildVoiceFallbackPlan(data.Voice.FallbackPlan),
+
+					Speed:                     valuePointer(data.Voice.Speed, data.Voice.Speed.ValueFloat64),
+					Style:                     valuePointer(data.Voice.Style, data.Voice.Style.ValueFloat64),
+					Emotion:                   valuePointer(data.Voice.Emotion, data.Voice.Emotion.ValueString),
+					Language:                  valuePointer(data.Voice.Language, data.Voice.Language.ValueString),
+					OptimizeStreamingLatency:  valuePointer(data.Voice.OptimizeStreamingLatency, data.Voice.OptimizeStreamingLatency.ValueInt64),
+					InputPreprocessingEnabled: valuePointer(data.Voice.InputPreprocessingEnabled, data.Voice.InputPreprocessingEnabled.ValueBool),
+					ChunkPlan:                 buildChunkPlan(data.Voice.ChunkPlan),
 				}
 			}
 			return nil
-		}(),
+		}(), prior.Voice != nil),
 
-		Model: func() *vapi.Model {
+		Model: objectValue(func() *vapi.Model {
 			if data.Model != nil {
 				return &vapi.Model{
-					Model:        data.Model.Model.ValueString(),
-					SystemPrompt: data.Model.SystemPrompt.ValueString(),
-					Provider:     data.Model.Provider.ValueString(),
-					MaxTokens:    data.Model.MaxTokens.ValueInt64(),
-					Temperature:  data.Model.Temperature.ValueFloat64(),
-					ToolIDs:      ElementsAsString(data.Model.ToolIDs),
+					Model:          data.Model.Model.ValueString(),
+					Messages:       buildModelMessages(data.Model),
+					Provider:       data.Model.Provider.ValueString(),
+					MaxTokens:      valuePointer(data.Model.MaxTokens, data.Model.MaxTokens.ValueInt64),
+					Temperature:    valuePointer(data.Model.Temperature, data.Model.Temperature.ValueFloat64),
+					ToolIDs:        ElementsAsString(data.Model.ToolIDs),
+					Tools:          buildModelTools(data.Model.Tools),
+					FallbackModels: buildFallbackModels(data.Model.FallbackModels),
 					KnowledgeBase: func() *vapi.KnowledgeBase {
 						if data.Model.KnowledgeBase != nil {
 							return &vapi.KnowledgeBase{
-								TopK:     data.Model.KnowledgeBase.TopK.ValueInt64(),
+								TopK:     valuePointer(data.Model.KnowledgeBase.TopK, data.Model.KnowledgeBase.TopK.ValueInt64),
 								FileIDs:  ElementsAsString(data.Model.KnowledgeBase.FileIDs),
 								Provider: data.Model.KnowledgeBase.Provider.ValueString(),
 							}
@@ -863,58 +1522,47 @@ func mapVAPIAssistantRequest(data *VAPIAssistantResourceModel) vapi.CreateAssist
 				}
 			}
 			return nil
-		}(),
+		}(), prior.Model != nil),
 
-		RecordingEnabled: data.RecordingEnabled.ValueBool(),
-		FirstMessage:     data.FirstMessage.ValueString(),
-		VoicemailMessage: data.VoicemailMessage.ValueString(),
-		EndCallMessage:   data.EndCallMessage.ValueString(),
+		RecordingEnabled: nullableValue(data.RecordingEnabled, prior.RecordingEnabled, data.RecordingEnabled.ValueBool),
+		FirstMessage:     nullableValue(data.FirstMessage, prior.FirstMessage, data.FirstMessage.ValueString),
+		VoicemailMessage: nullableValue(data.VoicemailMessage, prior.VoicemailMessage, data.VoicemailMessage.ValueString),
+		EndCallMessage:   nullableValue(data.EndCallMessage, prior.EndCallMessage, data.EndCallMessage.ValueString),
 
-		Transcriber: func() *vapi.Transcriber {
+		Transcriber: objectValue(func() *vapi.Transcriber {
 			if data.Transcriber != nil {
 				return &vapi.Transcriber{
-					Provider: data.Transcriber.Provider.ValueString(),
-					Model:    data.Transcriber.Model.ValueString(),
-					Language: data.Transcriber.Language.ValueString(),
+					Provider:     data.Transcriber.Provider.ValueString(),
+					Model:        valuePointer(data.Transcriber.Model, data.Transcriber.Model.ValueString),
+					Language:     valuePointer(data.Transcriber.Language, data.Transcriber.Language.ValueString),
+					FallbackPlan: buildTranscriberFallbackPlan(data.Transcriber.FallbackPlan),
 				}
 			}
 			return nil
-		}(),
+		}(), prior.Transcriber != nil),
 
-		Server: &vapi.Server{
-			URL:            data.ServerURL.ValueString(),
-			Secret:         data.ServerURLSecret.ValueString(),
-			TimeoutSeconds: 20,
-		},
+		Server: serverValue(
+			buildServer(data.Server, data.ServerURL, data.ServerURLSecret),
+			prior.Server != nil || !prior.ServerURL.IsNull(),
+		),
 
-		ClientMessages:     ElementsAsString(data.ClientMessages),
-		EndCallPhrases:     ElementsAsString(data.EndCallPhrases),
-		MaxDurationSeconds: data.MaxDurationSeconds.ValueInt64(),
-
-		AnalysisPlan: func() *vapi.AnalysisPlan {
-			if data.AnalysisPlan != nil {
-				return &vapi.AnalysisPlan{
-					SummaryPrompt:           data.AnalysisPlan.SummaryPrompt.ValueString(),
-					StructuredDataPrompt:    data.AnalysisPlan.StructuredDataPrompt.ValueString(),
-					StructuredDataSchema:    createRequestObject(data.AnalysisPlan.StructuredDataSchema),
-					SuccessEvaluationPrompt: data.AnalysisPlan.SuccessEvaluationPrompt.ValueString(),
-					SuccessEvaluationRubric: data.AnalysisPlan.SuccessEvaluationRubric.ValueString(),
-				}
-			}
-			return nil
-		}(),
+		ClientMessages:     computedNullableValue(data.ClientMessages, prior.ClientMessages, func() []string { return ElementsAsString(data.ClientMessages) }),
+		EndCallPhrases:     nullableValue(data.EndCallPhrases, prior.EndCallPhrases, func() []string { return ElementsAsString(data.EndCallPhrases) }),
+		MaxDurationSeconds: nullableValue(data.MaxDurationSeconds, prior.MaxDurationSeconds, data.MaxDurationSeconds.ValueInt64),
+
+		AnalysisPlan: objectValue(buildAnalysisPlan(data.AnalysisPlan, prior.AnalysisPlan), prior.AnalysisPlan != nil),
 
-		StartSpeakingPlan: func() *vapi.StartSpeakingPlan {
+		StartSpeakingPlan: objectValue(func() *vapi.StartSpeakingPlan {
 			if data.StartSpeakingPlan != nil {
 				return &vapi.StartSpeakingPlan{
-					WaitSeconds:             data.StartSpeakingPlan.WaitSeconds.ValueFloat64(),
-					SmartEndpointingEnabled: data.StartSpeakingPlan.SmartEndpointingEnabled.ValueBool(),
+					WaitSeconds:             valuePointer(data.StartSpeakingPlan.WaitSeconds, data.StartSpeakingPlan.WaitSeconds.ValueFloat64),
+					SmartEndpointingEnabled: valuePointer(data.StartSpeakingPlan.SmartEndpointingEnabled, data.StartSpeakingPlan.SmartEndpointingEnabled.ValueBool),
 					TranscriptionEndpointingPlan: func() *vapi.TranscriptionEndpointingPlan {
-						if data.StartSpeakingPlan.TranscriptionEndpointingPlan != nil {
+						if plan := data.StartSpeakingPlan.TranscriptionEndpointingPlan; plan != nil {
 							return &vapi.TranscriptionEndpointingPlan{
-								OnPunctuationSeconds:   data.StartSpeakingPlan.TranscriptionEndpointingPlan.OnPunctuationSeconds.ValueFloat64(),
-								OnNoPunctuationSeconds: data.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds.ValueFloat64(),
-								OnNumberSeconds:        data.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNumberSeconds.ValueFloat64(),
+								OnPunctuationSeconds:   valuePointer(plan.OnPunctuationSeconds, plan.OnPunctuationSeconds.ValueFloat64),
+								OnNoPunctuationSeconds: valuePointer(plan.OnNoPunctuationSeconds, plan.OnNoPunctuationSeconds.ValueFloat64),
+								OnNumberSeconds:        valuePointer(plan.OnNumberSeconds, plan.OnNumberSeconds.ValueFloat64),
 							}
 						}
 						return nil
@@ -922,34 +1570,520 @@ func mapVAPIAssistantRequest(data *VAPIAssistantResourceModel) vapi.CreateAssist
 				}
 			}
 			return nil
-		}(),
+		}(), prior.StartSpeakingPlan != nil),
 
-		StopSpeakingPlan: func() *vapi.StopSpeakingPlan {
+		StopSpeakingPlan: objectValue(func() *vapi.StopSpeakingPlan {
 			if data.StopSpeakingPlan != nil {
 				return &vapi.StopSpeakingPlan{
-					NumWords:       data.StopSpeakingPlan.NumWords.ValueFloat64(),
-					VoiceSeconds:   data.StopSpeakingPlan.VoiceSeconds.ValueFloat64(),
-					BackoffSeconds: data.StopSpeakingPlan.BackoffSeconds.ValueFloat64(),
+					NumWords:       valuePointer(data.StopSpeakingPlan.NumWords, data.StopSpeakingPlan.NumWords.ValueFloat64),
+					VoiceSeconds:   valuePointer(data.StopSpeakingPlan.VoiceSeconds, data.StopSpeakingPlan.VoiceSeconds.ValueFloat64),
+					BackoffSeconds: valuePointer(data.StopSpeakingPlan.BackoffSeconds, data.StopSpeakingPlan.BackoffSeconds.ValueFloat64),
 				}
 			}
 			return nil
-		}(),
-
-		ArtifactPlan: func() *vapi.ArtifactPlan {
-			if data.ArtifactPlan != nil {
-				var recordingFormat string
-				if len(data.ArtifactPlan.RecordingFormat.ValueString()) == 0 {
-					recordingFormat = "mp3"
-				} else {
-					recordingFormat = data.ArtifactPlan.RecordingFormat.ValueString()
-				}
-				return &vapi.ArtifactPlan{
-					RecordingFormat: recordingFormat,
-				}
+		}(), prior.StopSpeakingPlan != nil),
+
+		ArtifactPlan: objectValue(buildArtifactPlan(data.ArtifactPlan, prior.ArtifactPlan), prior.ArtifactPlan != nil),
+
+		VoicemailDetection: objectValue(buildVoicemailDetection(data.VoicemailDetection), prior.VoicemailDetection != nil),
+		KeypadInputPlan:    objectValue(buildKeypadInputPlan(data.KeypadInputPlan), prior.KeypadInputPlan != nil),
+		Hooks:              sliceValue(buildHooks(data.Hooks), len(prior.Hooks) > 0),
+	}
+}
+
+// Values accepted by voicemail_detection, keypad_input_plan and hooks.
+var (
+	voicemailDetectionProviders = []string{"twilio", "google", "openai", "vapi"}
+	keypadDelimiters            = []string{"#", "*", ""}
+	hookEvents                  = []string{"call.ending", "customer.speech.timeout"}
+	hookActionTypes             = []string{"say", "transfer"}
+)
+
+func buildVoicemailDetection(detection *VoicemailDetectionResourceModel) *vapi.VoicemailDetection {
+	if detection == nil {
+		return nil
+	}
+
+	result := &vapi.VoicemailDetection{
+		Provider:                detection.Provider.ValueString(),
+		MachineDetectionTimeout: valuePointer(detection.MachineDetectionTimeout, detection.MachineDetectionTimeout.ValueInt64),
+	}
+	if plan := detection.BackoffPlan; plan != nil {
+		result.BackoffPlan = &vapi.VoicemailDetectionBackoffPlan{
+			StartAtSeconds:   valuePointer(plan.StartAtSeconds, plan.StartAtSeconds.ValueFloat64),
+			FrequencySeconds: valuePointer(plan.FrequencySeconds, plan.FrequencySeconds.ValueFloat64),
+			MaxRetries:       valuePointer(plan.MaxRetries, plan.MaxRetries.ValueInt64),
+		}
+	}
+	return result
+}
+
+func flattenVoicemailDetection(detection *vapi.VoicemailDetection) *VoicemailDetectionResourceModel {
+	if detection == nil {
+		return nil
+	}
+
+	result := &VoicemailDetectionResourceModel{
+		Provider:                types.StringValue(detection.Provider),
+		MachineDetectionTimeout: types.Int64PointerValue(detection.MachineDetectionTimeout),
+	}
+	if plan := detection.BackoffPlan; plan != nil {
+		result.BackoffPlan = &VoicemailDetectionBackoffPlanResourceModel{
+			StartAtSeconds:   types.Float64PointerValue(plan.StartAtSeconds),
+			FrequencySeconds: types.Float64PointerValue(plan.FrequencySeconds),
+			MaxRetries:       types.Int64PointerValue(plan.MaxRetries),
+		}
+	}
+	return result
+}
+
+func buildKeypadInputPlan(plan *KeypadInputPlanResourceModel) *vapi.KeypadInputPlan {
+	if plan == nil {
+		return nil
+	}
+
+	return &vapi.KeypadInputPlan{
+		Enabled:        valuePointer(plan.Enabled, plan.Enabled.ValueBool),
+		TimeoutSeconds: valuePointer(plan.TimeoutSeconds, plan.TimeoutSeconds.ValueFloat64),
+		Delimiters:     ElementsAsString(plan.Delimiters),
+	}
+}
+
+func flattenKeypadInputPlan(plan *vapi.KeypadInputPlan) *KeypadInputPlanResourceModel {
+	if plan == nil {
+		return nil
+	}
+
+	return &KeypadInputPlanResourceModel{
+		Enabled:        types.BoolPointerValue(plan.Enabled),
+		TimeoutSeconds: types.Float64PointerValue(plan.TimeoutSeconds),
+		Delimiters:     listValueOrNull(plan.Delimiters),
+	}
+}
+
+func buildHooks(hooks []HookResourceModel) []vapi.Hook {
+	var result []vapi.Hook
+	for _, hook := range hooks {
+		actions := make([]vapi.HookAction, 0, len(hook.Do))
+		for _, action := range hook.Do {
+			item := vapi.HookAction{
+				Type:   action.Type.ValueString(),
+				Exact:  valuePointer(action.Exact, action.Exact.ValueString),
+				Prompt: valuePointer(action.Prompt, action.Prompt.ValueString),
 			}
-			return nil
-		}(),
+			if action.Destination != nil {
+				item.Destination = buildHookDestination(action.Destination)
+			}
+			actions = append(actions, item)
+		}
+
+		item := vapi.Hook{On: hook.On.ValueString(), Do: actions}
+		if options := hook.Options; options != nil {
+			item.Options = &vapi.HookOptions{
+				TimeoutSeconds:   valuePointer(options.TimeoutSeconds, options.TimeoutSeconds.ValueFloat64),
+				TriggerMaxCount:  valuePointer(options.TriggerMaxCount, options.TriggerMaxCount.ValueInt64),
+				TriggerResetMode: valuePointer(options.TriggerResetMode, options.TriggerResetMode.ValueString),
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// hookDestinationAttributes describes where a `transfer` hook action sends
+// the call. Unlike tool destinations, only the type is required.
+func hookDestinationAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Type of the destination: `number` or `sip`.",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("number", "sip"),
+			},
+		},
+		"number": schema.StringAttribute{
+			MarkdownDescription: "Phone number to transfer to. Required for `number` destinations.",
+			Optional:            true,
+			Validators: []validator.String{
+				supportedByTypes("number"),
+			},
+		},
+		"extension": schema.StringAttribute{
+			MarkdownDescription: "Extension to dial after the call is answered.",
+			Optional:            true,
+			Validators: []validator.String{
+				supportedByTypes("number"),
+			},
+		},
+		"caller_id": schema.StringAttribute{
+			MarkdownDescription: "Caller ID shown to the destination, for example `{{customer.number}}`.",
+			Optional:            true,
+			Validators: []validator.String{
+				supportedByTypes("number"),
+			},
+		},
+		"number_e164_check_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether the number must be in E.164 format. The API enables it by default.",
+			Optional:            true,
+			Validators: []validator.Bool{
+				supportedByTypes("number"),
+			},
+		},
+		"sip_uri": schema.StringAttribute{
+			MarkdownDescription: "SIP URI to transfer to. Required for `sip` destinations.",
+			Optional:            true,
+			Validators: []validator.String{
+				supportedByTypes("sip"),
+			},
+		},
+		"sip_headers": schema.MapAttribute{
+			MarkdownDescription: "Headers added to the SIP `REFER` or `INVITE`.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				supportedByTypes("sip"),
+			},
+		},
+		"message": schema.StringAttribute{
+			MarkdownDescription: "Message said to the customer before the transfer.",
+			Optional:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Description of the destination.",
+			Optional:            true,
+		},
+	}
+}
+
+func buildHookDestination(destination *HookDestinationResourceModel) *vapi.Destination {
+	return &buildTransferDestinations([]TransferDestinationResourceModel{{
+		Type:                   destination.Type,
+		Number:                 destination.Number,
+		Extension:              destination.Extension,
+		CallerID:               destination.CallerID,
+		NumberE164CheckEnabled: destination.NumberE164CheckEnabled,
+		SipURI:                 destination.SipURI,
+		SipHeaders:             destination.SipHeaders,
+		Message:                destination.Message,
+		Description:            destination.Description,
+	}})[0]
+}
+
+func flattenHookDestination(destination *vapi.Destination) *HookDestinationResourceModel {
+	dst := flattenTransferDestinations([]vapi.Destination{*destination})[0]
+	return &HookDestinationResourceModel{
+		Type:                   dst.Type,
+		Number:                 dst.Number,
+		Extension:              dst.Extension,
+		CallerID:               dst.CallerID,
+		NumberE164CheckEnabled: dst.NumberE164CheckEnabled,
+		SipURI:                 dst.SipURI,
+		SipHeaders:             dst.SipHeaders,
+		Message:                dst.Message,
+		Description:            dst.Description,
+	}
+}
+
+func flattenHooks(hooks []vapi.Hook) []HookResourceModel {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	result := make([]HookResourceModel, 0, len(hooks))
+	for _, hook := range hooks {
+		actions := make([]HookActionResourceModel, 0, len(hook.Do))
+		for _, action := range hook.Do {
+			item := HookActionResourceModel{
+				Type:   types.StringValue(action.Type),
+				Exact:  types.StringPointerValue(action.Exact),
+				Prompt: types.StringPointerValue(action.Prompt),
+			}
+			if action.Destination != nil {
+				item.Destination = flattenHookDestination(action.Destination)
+			}
+			actions = append(actions, item)
+		}
+
+		item := HookResourceModel{On: types.StringValue(hook.On), Do: actions}
+		if options := hook.Options; options != nil {
+			item.Options = &HookOptionsResourceModel{
+				TimeoutSeconds:   types.Float64PointerValue(options.TimeoutSeconds),
+				TriggerMaxCount:  types.Int64PointerValue(options.TriggerMaxCount),
+				TriggerResetMode: types.StringPointerValue(options.TriggerResetMode),
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// modelMessageRoles are the roles accepted for model.messages.
+var modelMessageRoles = []string{"system", "user", "assistant", "tool", "function"}
+
+// buildModelMessages returns the messages sent to the API: system_prompt as a
+// leading system message followed by messages in configuration order.
+func buildModelMessages(model *ModelResourceModel) []vapi.Message {
+	var messages []vapi.Message
+	if !model.SystemPrompt.IsNull() && !model.SystemPrompt.IsUnknown() {
+		messages = append(messages, vapi.Message{Role: "system", Content: model.SystemPrompt.ValueString()})
+	}
+	return append(messages, buildMessages(model.Messages)...)
+}
+
+func buildMessages(messages []ModelMessageResourceModel) []vapi.Message {
+	var result []vapi.Message
+	for _, message := range messages {
+		result = append(result, vapi.Message{
+			Role:    message.Role.ValueString(),
+			Content: message.Content.ValueString(),
+		})
+	}
+	return result
+}
+
+func flattenMessages(messages []vapi.Message) []ModelMessageResourceModel {
+	if len(messages) == 0 {
+		return nil
+	}
+	result := make([]ModelMessageResourceModel, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, ModelMessageResourceModel{
+			Role:    types.StringValue(message.Role),
+			Content: types.StringValue(message.Content),
+		})
+	}
+	return result
+}
+
+// splitModelMessages is the inverse of buildModelMessages. A leading system
+// message becomes system_prompt, unless the prior model configured it as the
+// first entry of messages without a system_prompt. Responses that still carry
+// the legacy systemPrompt field and no system message use that instead.
+func splitModelMessages(prior *ModelResourceModel, model *vapi.Model) (types.String, []ModelMessageResourceModel) {
+	systemPrompt := types.StringPointerValue(model.SystemPrompt)
+	remaining := model.Messages
+
+	keepLeadingSystem := prior != nil && prior.SystemPrompt.IsNull() &&
+		len(prior.Messages) > 0 && prior.Messages[0].Role.ValueString() == "system"
+	if len(remaining) > 0 && remaining[0].Role == "system" && !keepLeadingSystem {
+		systemPrompt = types.StringValue(remaining[0].Content)
+		remaining = remaining[1:]
+	}
+
+	if len(remaining) == 0 {
+		if prior != nil && prior.Messages != nil {
+			return systemPrompt, []ModelMessageResourceModel{}
+		}
+		return systemPrompt, nil
+	}
+
+	return systemPrompt, flattenMessages(remaining)
+}
+
+// Providers accepted for fallback voices, transcribers and models, and the
+// ones that support each fallback setting. Custom transcribers only take a
+// server, and custom LLMs need a URL that fallback models cannot carry.
+var (
+	voiceProviders = []string{
+		"11labs", "azure", "cartesia", "custom-voice", "deepgram", "hume", "inworld", "lmnt", "minimax",
+		"neuphonic", "openai", "playht", "rime-ai", "sesame", "smallest-ai", "tavus", "vapi",
+	}
+	voiceSpeedProviders    = []string{"11labs", "azure", "lmnt", "minimax", "openai", "playht", "rime-ai", "vapi"}
+	voiceLanguageProviders = []string{"11labs", "cartesia", "lmnt", "minimax", "playht"}
+	transcriberProviders   = []string{
+		"11labs", "assembly-ai", "azure", "cartesia", "custom-transcriber", "deepgram", "gladia",
+		"google", "openai", "speechmatics", "talkscriber",
+	}
+	transcriberSettingProviders = []string{
+		"11labs", "assembly-ai", "azure", "cartesia", "deepgram", "gladia",
+		"google", "openai", "speechmatics", "talkscriber",
+	}
+	fallbackModelProviders = []string{
+		"anthropic", "anyscale", "azure-openai", "cerebras", "deepinfra", "deepseek",
+		"google", "groq", "inflection-ai", "openai", "openrouter", "perplexity-ai", "runpod",
+		"together-ai", "vapi", "xai",
+	}
+)
+
+func buildVoiceFallbackPlan(plan *VoiceFallbackPlanResourceModel) *vapi.VoiceFallbackPlan {
+	if plan == nil {
+		return nil
+	}
+
+	voices := make([]vapi.Voice, 0, len(plan.Voices))
+	for _, voice := range plan.Voices {
+		voices = append(voices, vapi.Voice{
+			Provider:        voice.Provider.ValueString(),
+			VoiceID:         voice.VoiceID.ValueString(),
+			Model:           voice.Model.ValueString(),
+			Stability:       valuePointer(voice.Stability, voice.Stability.ValueFloat64),
+			SimilarityBoost: valuePointer(voice.SimilarityBoost, voice.SimilarityBoost.ValueFloat64),
+		})
+	}
+	return &vapi.VoiceFallbackPlan{Voices: voices}
+}
+
+func flattenVoiceFallbackPlan(plan *vapi.VoiceFallbackPlan) *VoiceFallbackPlanResourceModel {
+	if plan == nil || len(plan.Voices) == 0 {
+		return nil
+	}
+
+	voices := make([]FallbackVoiceResourceModel, 0, len(plan.Voices))
+	for _, voice := range plan.Voices {
+		voices = append(voices, FallbackVoiceResourceModel{
+			Provider:        types.StringValue(voice.Provider),
+			VoiceID:         types.StringValue(voice.VoiceID),
+			Model:           stringValueOrNull(voice.Model),
+			Stability:       types.Float64PointerValue(voice.Stability),
+			SimilarityBoost: types.Float64PointerValue(voice.SimilarityBoost),
+		})
 	}
+	return &VoiceFallbackPlanResourceModel{Voices: voices}
+}
+
+func buildChunkPlan(plan *ChunkPlanResourceModel) *vapi.ChunkPlan {
+	if plan == nil {
+		return nil
+	}
+
+	return &vapi.ChunkPlan{
+		Enabled:               valuePointer(plan.Enabled, plan.Enabled.ValueBool),
+		MinCharacters:         valuePointer(plan.MinCharacters, plan.MinCharacters.ValueInt64),
+		PunctuationBoundaries: ElementsAsString(plan.PunctuationBoundaries),
+	}
+}
+
+func flattenChunkPlan(plan *vapi.ChunkPlan) *ChunkPlanResourceModel {
+	if plan == nil {
+		return nil
+	}
+
+	return &ChunkPlanResourceModel{
+		Enabled:               types.BoolPointerValue(plan.Enabled),
+		MinCharacters:         types.Int64PointerValue(plan.MinCharacters),
+		PunctuationBoundaries: listValueOrNull(plan.PunctuationBoundaries),
+	}
+}
+
+func buildTranscriberFallbackPlan(plan *TranscriberFallbackPlanResourceModel) *vapi.TranscriberFallbackPlan {
+	if plan == nil {
+		return nil
+	}
+
+	transcribers := make([]vapi.Transcriber, 0, len(plan.Transcribers))
+	for _, transcriber := range plan.Transcribers {
+		transcribers = append(transcribers, vapi.Transcriber{
+			Provider: transcriber.Provider.ValueString(),
+			Model:    valuePointer(transcriber.Model, transcriber.Model.ValueString),
+			Language: valuePointer(transcriber.Language, transcriber.Language.ValueString),
+		})
+	}
+	return &vapi.TranscriberFallbackPlan{Transcribers: transcribers}
+}
+
+func flattenTranscriberFallbackPlan(plan *vapi.TranscriberFallbackPlan) *TranscriberFallbackPlanResourceModel {
+	if plan == nil || len(plan.Transcribers) == 0 {
+		return nil
+	}
+
+	transcribers := make([]FallbackTranscriberResourceModel, 0, len(plan.Transcribers))
+	for _, transcriber := range plan.Transcribers {
+		transcribers = append(transcribers, FallbackTranscriberResourceModel{
+			Provider: types.StringValue(transcriber.Provider),
+			Model:    types.StringPointerValue(transcriber.Model),
+			Language: types.StringPointerValue(transcriber.Language),
+		})
+	}
+	return &TranscriberFallbackPlanResourceModel{Transcribers: transcribers}
+}
+
+func buildFallbackModels(models []FallbackModelResourceModel) []vapi.FallbackModel {
+	var result []vapi.FallbackModel
+	for _, model := range models {
+		result = append(result, vapi.FallbackModel{
+			Provider: model.Provider.ValueString(),
+			Model:    model.Model.ValueString(),
+		})
+	}
+	return result
+}
+
+func flattenFallbackModels(models []vapi.FallbackModel) []FallbackModelResourceModel {
+	if len(models) == 0 {
+		return nil
+	}
+
+	result := make([]FallbackModelResourceModel, 0, len(models))
+	for _, model := range models {
+		result = append(result, FallbackModelResourceModel{
+			Provider: types.StringValue(model.Provider),
+			Model:    types.StringValue(model.Model),
+		})
+	}
+	return result
+}
+
+// modelToolTypes are the tool types that can be defined inline on a model.
+var modelToolTypes = []string{"transferCall", "endCall", "function", "dtmf"}
+
+func buildModelTools(tools []ModelToolResourceModel) []vapi.ModelTool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	result := make([]vapi.ModelTool, 0, len(tools))
+	for _, tool := range tools {
+		modelTool := vapi.ModelTool{
+			Type:         tool.Type.ValueString(),
+			Async:        valuePointer(tool.Async, tool.Async.ValueBool),
+			Messages:     buildToolMessages(tool.Messages),
+			Destinations: buildDestinations(tool.Destinations),
+		}
+		if tool.Function != nil {
+			modelTool.Function = &vapi.Function{
+				Name:        tool.Function.Name.ValueString(),
+				Description: tool.Function.Description.ValueString(),
+				Async:       tool.Async.ValueBool(),
+				Parameters:  buildFunctionParameters(tool.Function.Parameters),
+			}
+		}
+		modelTool.Server = buildServer(tool.Server, types.StringNull(), types.StringNull())
+		result = append(result, modelTool)
+	}
+	return result
+}
+
+// flattenModelTools maps inline tools from the API. Server secrets are not
+// returned, so they are carried over from the tool at the same position in
+// prior.
+func flattenModelTools(prior []ModelToolResourceModel, tools []vapi.ModelTool) []ModelToolResourceModel {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	result := make([]ModelToolResourceModel, 0, len(tools))
+	for i, tool := range tools {
+		model := ModelToolResourceModel{
+			Type:         types.StringValue(tool.Type),
+			Async:        types.BoolPointerValue(tool.Async),
+			Messages:     flattenToolMessages(tool.Messages),
+			Destinations: flattenDestinations(tool.Destinations),
+		}
+		if tool.Function != nil {
+			model.Function = &ModelToolFunctionModel{
+				Name:        types.StringValue(tool.Function.Name),
+				Description: stringValueOrNull(tool.Function.Description),
+				Parameters:  flattenFunctionParameters(tool.Function.Parameters),
+			}
+		}
+		var priorServer *ServerResourceModel
+		if i < len(prior) {
+			priorServer = prior[i].Server
+		}
+		model.Server = flattenServerBlock(tool.Server, priorServer)
+		result = append(result, model)
+	}
+	return result
 }
 
 func createRequestObject(data *StructuredDataSchemaResourceModel) *vapi.StructuredDataSchema {
@@ -971,6 +2105,70 @@ func createRequestObject(data *StructuredDataSchemaResourceModel) *vapi.Structur
 	}
 }
 
+// buildStructuredDataSchema returns the structured data schema sent to the API,
+// from either structured_data_schema_json or the deprecated flat block.
+func buildStructuredDataSchema(plan *AnalysisPlanResourceModel) json.RawMessage {
+	if raw := normalizedRawMessage(plan.StructuredDataSchemaJSON); raw != nil {
+		return raw
+	}
+
+	flat := createRequestObject(plan.StructuredDataSchema)
+	if flat == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(flat)
+	return raw
+}
+
+// structuredDataSchemaValue wraps the structured data schema for a request
+// body, sending an explicit null when prior state had a schema in either form
+// and plan has none.
+func structuredDataSchemaValue(plan, prior *AnalysisPlanResourceModel) vapi.Nullable[json.RawMessage] {
+	if raw := buildStructuredDataSchema(plan); raw != nil {
+		return vapi.NewNullable(raw)
+	}
+	if prior.StructuredDataSchema != nil || !prior.StructuredDataSchemaJSON.IsNull() {
+		return vapi.ExplicitNull[json.RawMessage]()
+	}
+	return vapi.Nullable[json.RawMessage]{}
+}
+
+// flattenStructuredDataSchema maps the structured data schema from the API
+// into the form the prior state uses: the deprecated flat block when it is
+// set, structured_data_schema_json otherwise. The JSON is stored as returned;
+// semantic equality keeps the configured formatting when only key order or
+// whitespace differs.
+func flattenStructuredDataSchema(raw json.RawMessage, prior *AnalysisPlanResourceModel) (*StructuredDataSchemaResourceModel, jsontypes.Normalized) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, jsontypes.NewNormalizedNull()
+	}
+
+	if prior != nil && prior.StructuredDataSchema != nil {
+		var flat vapi.StructuredDataSchema
+		if err := json.Unmarshal(raw, &flat); err == nil {
+			return mapStructuredDataSchemaRequestToResourceModel(&flat), jsontypes.NewNormalizedNull()
+		}
+	}
+	return nil, normalizedJSON(raw)
+}
+
+// normalizedJSON returns raw as a JSON string value, or null when the API
+// returned nothing.
+func normalizedJSON(raw json.RawMessage) jsontypes.Normalized {
+	if len(raw) == 0 || string(raw) == "null" {
+		return jsontypes.NewNormalizedNull()
+	}
+	return jsontypes.NewNormalizedValue(string(raw))
+}
+
+// normalizedRawMessage returns the JSON of value, or nil when it is not set.
+func normalizedRawMessage(value jsontypes.Normalized) json.RawMessage {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return json.RawMessage(value.ValueString())
+}
+
 func mapStructuredDataSchemaRequestToResourceModel(data *vapi.StructuredDataSchema) *StructuredDataSchemaResourceModel {
 	if data == nil {
 		return nil
@@ -989,3 +2187,192 @@ func mapStructuredDataSchemaRequestToResourceModel(data *vapi.StructuredDataSche
 		Properties: properties,
 	}
 }
+
+// Rubrics accepted by success_evaluation_plan.
+var successEvaluationRubrics = []string{
+	"NumericScale", "DescriptiveScale", "Checklist", "Matrix", "PercentageScale", "LikertScale", "AutomaticRubric", "PassFail",
+}
+
+// analysisSubPlanAttribute returns the schema of an analysis_plan sub-plan:
+// messages, enabled and timeout_seconds, plus the plan-specific attributes.
+func analysisSubPlanAttribute(description string, attributes map[string]schema.Attribute) schema.SingleNestedAttribute {
+	result := map[string]schema.Attribute{
+		"messages": schema.ListNestedAttribute{
+			MarkdownDescription: "Prompt messages sent to the model that runs the analysis. `{{transcript}}` and `{{systemPrompt}}` are replaced with the call transcript and the assistant system prompt.",
+			Optional:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"role": schema.StringAttribute{
+						MarkdownDescription: "Role of the message author: system, user, assistant, tool or function.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(modelMessageRoles...),
+						},
+					},
+					"content": schema.StringAttribute{
+						MarkdownDescription: "Content of the message.",
+						Required:            true,
+					},
+				},
+			},
+		},
+		"enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether the analysis runs. The API enables it by default.",
+			Optional:            true,
+		},
+		"timeout_seconds": schema.Float64Attribute{
+			MarkdownDescription: "Seconds to wait for the analysis, between 1 and 60.",
+			Optional:            true,
+			Validators: []validator.Float64{
+				float64validator.Between(1, 60),
+			},
+		},
+	}
+	for name, attribute := range attributes {
+		result[name] = attribute
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes:          result,
+	}
+}
+
+// buildAnalysisPlan maps the analysis plan for a request body. Settings that
+// prior state had and plan no longer sets are cleared with an explicit null.
+func buildAnalysisPlan(plan, prior *AnalysisPlanResourceModel) *vapi.AnalysisPlanRequest {
+	if plan == nil {
+		return nil
+	}
+	if prior == nil {
+		prior = &AnalysisPlanResourceModel{}
+	}
+
+	return &vapi.AnalysisPlanRequest{
+		SummaryPrompt:           nullableValue(plan.SummaryPrompt, prior.SummaryPrompt, plan.SummaryPrompt.ValueString),
+		StructuredDataPrompt:    nullableValue(plan.StructuredDataPrompt, prior.StructuredDataPrompt, plan.StructuredDataPrompt.ValueString),
+		StructuredDataSchema:    structuredDataSchemaValue(plan, prior),
+		SuccessEvaluationPrompt: nullableValue(plan.SuccessEvaluationPrompt, prior.SuccessEvaluationPrompt, plan.SuccessEvaluationPrompt.ValueString),
+		SuccessEvaluationRubric: nullableValue(plan.SuccessEvaluationRubric, prior.SuccessEvaluationRubric, plan.SuccessEvaluationRubric.ValueString),
+		SummaryPlan:             objectValue(buildSummaryPlan(plan.SummaryPlan), prior.SummaryPlan != nil),
+		StructuredDataPlan:      objectValue(buildStructuredDataPlan(plan.StructuredDataPlan), prior.StructuredDataPlan != nil),
+		SuccessEvaluationPlan:   objectValue(buildSuccessEvaluationPlan(plan.SuccessEvaluationPlan), prior.SuccessEvaluationPlan != nil),
+	}
+}
+
+// buildArtifactPlan maps the artifact plan for a request body, clearing
+// settings removed since prior state like buildAnalysisPlan. The recording
+// format defaults to mp3.
+func buildArtifactPlan(plan, prior *ArtifactPlanResourceModel) *vapi.ArtifactPlanRequest {
+	if plan == nil {
+		return nil
+	}
+	if prior == nil {
+		prior = &ArtifactPlanResourceModel{}
+	}
+
+	recordingFormat := plan.RecordingFormat.ValueString()
+	if recordingFormat == "" {
+		recordingFormat = "mp3"
+	}
+	return &vapi.ArtifactPlanRequest{
+		RecordingFormat:       recordingFormat,
+		RecordingPath:         nullableValue(plan.RecordingPath, prior.RecordingPath, plan.RecordingPath.ValueString),
+		VideoRecordingEnabled: nullableValue(plan.VideoRecordingEnabled, prior.VideoRecordingEnabled, plan.VideoRecordingEnabled.ValueBool),
+		PcapEnabled:           nullableValue(plan.PcapEnabled, prior.PcapEnabled, plan.PcapEnabled.ValueBool),
+		TranscriptPlan:        objectValue(buildTranscriptPlan(plan.TranscriptPlan), prior.TranscriptPlan != nil),
+	}
+}
+
+func buildSummaryPlan(plan *SummaryPlanResourceModel) *vapi.SummaryPlan {
+	if plan == nil {
+		return nil
+	}
+	return &vapi.SummaryPlan{
+		Messages:       buildMessages(plan.Messages),
+		Enabled:        valuePointer(plan.Enabled, plan.Enabled.ValueBool),
+		TimeoutSeconds: valuePointer(plan.TimeoutSeconds, plan.TimeoutSeconds.ValueFloat64),
+	}
+}
+
+func flattenSummaryPlan(plan *vapi.SummaryPlan) *SummaryPlanResourceModel {
+	if plan == nil {
+		return nil
+	}
+	return &SummaryPlanResourceModel{
+		Messages:       flattenMessages(plan.Messages),
+		Enabled:        types.BoolPointerValue(plan.Enabled),
+		TimeoutSeconds: types.Float64PointerValue(plan.TimeoutSeconds),
+	}
+}
+
+func buildStructuredDataPlan(plan *StructuredDataPlanResourceModel) *vapi.StructuredDataPlan {
+	if plan == nil {
+		return nil
+	}
+	return &vapi.StructuredDataPlan{
+		Messages:       buildMessages(plan.Messages),
+		Enabled:        valuePointer(plan.Enabled, plan.Enabled.ValueBool),
+		Schema:         normalizedRawMessage(plan.SchemaJSON),
+		TimeoutSeconds: valuePointer(plan.TimeoutSeconds, plan.TimeoutSeconds.ValueFloat64),
+	}
+}
+
+func flattenStructuredDataPlan(plan *vapi.StructuredDataPlan) *StructuredDataPlanResourceModel {
+	if plan == nil {
+		return nil
+	}
+	return &StructuredDataPlanResourceModel{
+		Messages:       flattenMessages(plan.Messages),
+		Enabled:        types.BoolPointerValue(plan.Enabled),
+		SchemaJSON:     normalizedJSON(plan.Schema),
+		TimeoutSeconds: types.Float64PointerValue(plan.TimeoutSeconds),
+	}
+}
+
+func buildSuccessEvaluationPlan(plan *SuccessEvaluationPlanResourceModel) *vapi.SuccessEvaluationPlan {
+	if plan == nil {
+		return nil
+	}
+	return &vapi.SuccessEvaluationPlan{
+		Rubric:         valuePointer(plan.Rubric, plan.Rubric.ValueString),
+		Messages:       buildMessages(plan.Messages),
+		Enabled:        valuePointer(plan.Enabled, plan.Enabled.ValueBool),
+		TimeoutSeconds: valuePointer(plan.TimeoutSeconds, plan.TimeoutSeconds.ValueFloat64),
+	}
+}
+
+func flattenSuccessEvaluationPlan(plan *vapi.SuccessEvaluationPlan) *SuccessEvaluationPlanResourceModel {
+	if plan == nil {
+		return nil
+	}
+	return &SuccessEvaluationPlanResourceModel{
+		Rubric:         types.StringPointerValue(plan.Rubric),
+		Messages:       flattenMessages(plan.Messages),
+		Enabled:        types.BoolPointerValue(plan.Enabled),
+		TimeoutSeconds: types.Float64PointerValue(plan.TimeoutSeconds),
+	}
+}
+
+func buildTranscriptPlan(plan *TranscriptPlanResourceModel) *vapi.TranscriptPlan {
+	if plan == nil {
+		return nil
+	}
+	return &vapi.TranscriptPlan{
+		Enabled:       valuePointer(plan.Enabled, plan.Enabled.ValueBool),
+		AssistantName: valuePointer(plan.AssistantName, plan.AssistantName.ValueString),
+		UserName:      valuePointer(plan.UserName, plan.UserName.ValueString),
+	}
+}
+
+func flattenTranscriptPlan(plan *vapi.TranscriptPlan) *TranscriptPlanResourceModel {
+	if plan == nil {
+		return nil
+	}
+	return &TranscriptPlanResourceModel{
+		Enabled:       types.BoolPointerValue(plan.Enabled),
+		AssistantName: types.StringPointerValue(plan.AssistantName),
+		UserName:      types.StringPointerValue(plan.UserName),
+	}
+}
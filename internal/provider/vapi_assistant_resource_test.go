@@ -1,14 +1,26 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
 )
@@ -22,39 +34,39 @@ func TestVAPIAssistantResourceLifecycle(t *testing.T) {
 		ID:                        "assistant-1",
 		OrgID:                     "org-1",
 		Name:                      "assistant",
-		FirstMessageMode:          "immediate",
-		HipaaEnabled:              true,
+		FirstMessageMode:          ptr("immediate"),
+		HipaaEnabled:              ptr(true),
 		ClientMessages:            []string{"client-1"},
 		ServerMessages:            []string{"server-1"},
-		BackgroundSound:           "lofi",
-		BackgroundDenoising:       true,
-		ModelOutputEnabled:        true,
-		Language:                  "en",
-		ForwardingPhoneNumber:     "+123456789",
-		InterruptionsEnabled:      true,
-		EndCallFunctionEnabled:    true,
-		DialKeypadFunctionEnabled: true,
-		FillersEnabled:            false,
-		MaxDurationSeconds:        120,
+		BackgroundSound:           ptr("lofi"),
+		BackgroundDenoising:       ptr(true),
+		ModelOutputEnabled:        ptr(true),
+		Language:                  ptr("en"),
+		ForwardingPhoneNumber:     ptr("+123456789"),
+		InterruptionsEnabled:      ptr(true),
+		EndCallFunctionEnabled:    ptr(true),
+		DialKeypadFunctionEnabled: ptr(true),
+		FillersEnabled:            ptr(false),
+		MaxDurationSeconds:        ptr(int64(120)),
 		AnalysisPlan: &vapi.AnalysisPlan{
-			SummaryPrompt:           "summary",
-			StructuredDataPrompt:    "structured",
-			StructuredDataSchema:    &vapi.StructuredDataSchema{Type: "object", Properties: map[string]*vapi.Property{"field": {Type: "string", Description: "desc"}}},
-			SuccessEvaluationPrompt: "success?",
-			SuccessEvaluationRubric: "rubric",
+			SummaryPrompt:           ptr("summary"),
+			StructuredDataPrompt:    ptr("structured"),
+			StructuredDataSchema:    json.RawMessage(`{"type":"object","properties":{"field":{"type":"string","description":"desc"}}}`),
+			SuccessEvaluationPrompt: ptr("success?"),
+			SuccessEvaluationRubric: ptr("rubric"),
 		},
 		MessagePlan: &vapi.MessagePlan{
 			IdleMessages: []string{"idle"},
 		},
 		Model: &vapi.Model{
 			Model:        "gpt-4",
-			SystemPrompt: "system",
+			SystemPrompt: ptr("system"),
 			Provider:     "openai",
-			MaxTokens:    4096,
-			Temperature:  0.5,
+			MaxTokens:    ptr(int64(4096)),
+			Temperature:  ptr(0.5),
 			ToolIDs:      []string{"tool-1"},
 			KnowledgeBase: &vapi.KnowledgeBase{
-				TopK:     3,
+				TopK:     ptr(int64(3)),
 				FileIDs:  []string{"file-1"},
 				Provider: "kb-provider",
 			},
@@ -63,8 +75,8 @@ func TestVAPIAssistantResourceLifecycle(t *testing.T) {
 			Model:           "voice-model",
 			Provider:        "voice-provider",
 			VoiceID:         "voice-1",
-			Stability:       0.9,
-			SimilarityBoost: 0.8,
+			Stability:       ptr(0.9),
+			SimilarityBoost: ptr(0.8),
 		},
 	})
 
@@ -72,44 +84,44 @@ func TestVAPIAssistantResourceLifecycle(t *testing.T) {
 		ID:                        "assistant-1",
 		OrgID:                     "org-1",
 		Name:                      "assistant-updated",
-		FirstMessageMode:          "immediate",
-		HipaaEnabled:              true,
+		FirstMessageMode:          ptr("immediate"),
+		HipaaEnabled:              ptr(true),
 		ClientMessages:            []string{"client-1"},
 		ServerMessages:            []string{"server-1"},
-		BackgroundSound:           "lofi",
-		BackgroundDenoising:       true,
-		ModelOutputEnabled:        true,
-		Language:                  "en",
-		ForwardingPhoneNumber:     "+123456789",
-		InterruptionsEnabled:      true,
-		EndCallFunctionEnabled:    true,
-		DialKeypadFunctionEnabled: true,
-		FillersEnabled:            false,
-		MaxDurationSeconds:        120,
+		BackgroundSound:           ptr("lofi"),
+		BackgroundDenoising:       ptr(true),
+		ModelOutputEnabled:        ptr(true),
+		Language:                  ptr("en"),
+		ForwardingPhoneNumber:     ptr("+123456789"),
+		InterruptionsEnabled:      ptr(true),
+		EndCallFunctionEnabled:    ptr(true),
+		DialKeypadFunctionEnabled: ptr(true),
+		FillersEnabled:            ptr(false),
+		MaxDurationSeconds:        ptr(int64(120)),
 		AnalysisPlan: &vapi.AnalysisPlan{
-			SummaryPrompt:           "summary",
-			StructuredDataPrompt:    "structured",
-			StructuredDataSchema:    &vapi.StructuredDataSchema{Type: "object", Properties: map[string]*vapi.Property{"field": {Type: "string", Description: "desc"}}},
-			SuccessEvaluationPrompt: "success?",
-			SuccessEvaluationRubric: "rubric",
+			SummaryPrompt:           ptr("summary"),
+			StructuredDataPrompt:    ptr("structured"),
+			StructuredDataSchema:    json.RawMessage(`{"type":"object","properties":{"field":{"type":"string","description":"desc"}}}`),
+			SuccessEvaluationPrompt: ptr("success?"),
+			SuccessEvaluationRubric: ptr("rubric"),
 		},
 		MessagePlan: &vapi.MessagePlan{
 			IdleMessages: []string{"idle"},
 		},
 		Model: &vapi.Model{
 			Model:        "gpt-4",
-			SystemPrompt: "system",
+			SystemPrompt: ptr("system"),
 			Provider:     "openai",
-			MaxTokens:    4096,
-			Temperature:  0.5,
+			MaxTokens:    ptr(int64(4096)),
+			Temperature:  ptr(0.5),
 			ToolIDs:      []string{"tool-1"},
 		},
 		Voice: &vapi.Voice{
 			Model:           "voice-model",
 			Provider:        "voice-provider",
 			VoiceID:         "voice-1",
-			Stability:       0.9,
-			SimilarityBoost: 0.8,
+			Stability:       ptr(0.9),
+			SimilarityBoost: ptr(0.8),
 		},
 	})
 
@@ -182,6 +194,102 @@ func TestVAPIAssistantResourceLifecycle(t *testing.T) {
 	transport.assertDrained()
 }
 
+func TestVAPIAssistantResourceImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	getResponse := []byte(`{
+		"id": "assistant-1",
+		"orgId": "org-1",
+		"name": "dashboard assistant",
+		"firstMessage": "hello",
+		"endCallMessage": "goodbye",
+		"server": {"url": "https://hook.example.com", "timeoutSeconds": 20},
+		"analysisPlan": {
+			"summaryPrompt": "summary",
+			"structuredDataPrompt": "structured",
+			"structuredDataSchema": {"type": "object", "properties": {"field": {"type": "string", "description": "desc"}}},
+			"successEvaluationPrompt": "success?",
+			"successEvaluationRubric": "NumericScale"
+		},
+		"messagePlan": {"idleMessages": ["still there?"]},
+		"startSpeakingPlan": {
+			"waitSeconds": 0.4,
+			"smartEndpointingEnabled": true,
+			"transcriptionEndpointingPlan": {"onPunctuationSeconds": 0.1, "onNoPunctuationSeconds": 1.5, "onNumberSeconds": 0.5}
+		},
+		"stopSpeakingPlan": {"numWords": 2, "voiceSeconds": 0.2, "backoffSeconds": 1},
+		"artifactPlan": {"recordingFormat": "wav"}
+	}`)
+
+	transport := &queueRoundTripper{
+		t: t,
+		responses: []queuedResponse{
+			{method: http.MethodGet, path: "/assistant/assistant-1", status: 200, body: getResponse},
+		},
+	}
+
+	res := &VAPIAssistantResource{
+		client: &vapi.APIClient{
+			BaseURL:    "https://api.example.com",
+			Token:      "token",
+			HTTPClient: &http.Client{Transport: transport},
+		},
+	}
+
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	importResp := resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	importResp.State.Raw = tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+	res.ImportState(ctx, resource.ImportStateRequest{ID: "assistant-1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import diagnostics: %v", importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var state VAPIAssistantResourceModel
+	if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state diagnostics: %v", diags)
+	}
+
+	if state.Name.ValueString() != "dashboard assistant" || state.EndCallMessage.ValueString() != "goodbye" {
+		t.Fatalf("unexpected top-level fields: %+v", state)
+	}
+	// Imported assistants use the server block rather than the deprecated
+	// flat attributes.
+	if !state.ServerURL.IsNull() || state.Server == nil ||
+		state.Server.URL.ValueString() != "https://hook.example.com" || !state.Server.Secret.IsNull() {
+		t.Fatalf("unexpected server %+v / server_url %s", state.Server, state.ServerURL)
+	}
+	// Imported structured data schemas use the JSON form.
+	if state.AnalysisPlan == nil || state.AnalysisPlan.StructuredDataSchema != nil ||
+		!strings.Contains(state.AnalysisPlan.StructuredDataSchemaJSON.ValueString(), `"desc"`) {
+		t.Fatalf("analysis plan not populated: %+v", state.AnalysisPlan)
+	}
+	if state.MessagePlan == nil || len(state.MessagePlan.IdleMessages.Elements()) != 1 {
+		t.Fatalf("message plan not populated: %+v", state.MessagePlan)
+	}
+	if state.StartSpeakingPlan == nil || state.StartSpeakingPlan.TranscriptionEndpointingPlan == nil ||
+		state.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds.ValueFloat64() != 1.5 {
+		t.Fatalf("start speaking plan not populated: %+v", state.StartSpeakingPlan)
+	}
+	if state.StopSpeakingPlan == nil || state.StopSpeakingPlan.BackoffSeconds.ValueFloat64() != 1 {
+		t.Fatalf("stop speaking plan not populated: %+v", state.StopSpeakingPlan)
+	}
+	if state.ArtifactPlan == nil || state.ArtifactPlan.RecordingFormat.ValueString() != "wav" {
+		t.Fatalf("artifact plan not populated: %+v", state.ArtifactPlan)
+	}
+
+	transport.assertDrained()
+}
+
 func assistantTestModel() VAPIAssistantResourceModel {
 	list := func(values ...string) types.List {
 		return ListValueFromStrings(values)
@@ -276,6 +384,10 @@ func assistantTestModel() VAPIAssistantResourceModel {
 	}
 }
 
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func mustMarshal(t *testing.T, v interface{}) []byte {
 	t.Helper()
 	data, err := json.Marshal(v)
@@ -284,3 +396,1347 @@ func mustMarshal(t *testing.T, v interface{}) []byte {
 	}
 	return data
 }
+
+// assistantScalarCases lists every optional scalar of the assistant together
+// with its JSON path. set assigns the attribute's zero value (false, 0 or "").
+var assistantScalarCases = []struct {
+	path string
+	zero string
+	set  func(m *VAPIAssistantResourceModel)
+	get  func(m *VAPIAssistantResourceModel) attr.Value
+}{
+	{"firstMessageMode", `""`, func(m *VAPIAssistantResourceModel) { m.FirstMessageMode = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.FirstMessageMode }},
+	{"hipaaEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.HipaaEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.HipaaEnabled }},
+	{"backgroundSound", `""`, func(m *VAPIAssistantResourceModel) { m.BackgroundSound = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.BackgroundSound }},
+	{"backgroundDenoisingEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.BackgroundDenoising = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.BackgroundDenoising }},
+	{"modelOutputInMessagesEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.ModelOutputEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.ModelOutputEnabled }},
+	{"language", `""`, func(m *VAPIAssistantResourceModel) { m.Language = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.Language }},
+	{"forwardingPhoneNumber", `""`, func(m *VAPIAssistantResourceModel) { m.ForwardingPhoneNumber = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.ForwardingPhoneNumber }},
+	{"interruptionsEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.InterruptionsEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.InterruptionsEnabled }},
+	{"endCallFunctionEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.EndCallFunctionEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.EndCallFunctionEnabled }},
+	{"dialKeypadFunctionEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.DialKeypadFunctionEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.DialKeypadFunctionEnabled }},
+	{"fillersEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.FillersEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.FillersEnabled }},
+	{"silenceTimeoutSeconds", `0`, func(m *VAPIAssistantResourceModel) { m.SilenceTimeoutSeconds = types.Float64Value(0) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.SilenceTimeoutSeconds }},
+	{"responseDelaySeconds", `0`, func(m *VAPIAssistantResourceModel) { m.ResponseDelaySeconds = types.Float64Value(0) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.ResponseDelaySeconds }},
+	{"numWordsToInterruptAssistant", `0`, func(m *VAPIAssistantResourceModel) { m.NumWordsToInterruptAssistant = types.Int64Value(0) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.NumWordsToInterruptAssistant }},
+	{"liveTranscriptsEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.LiveTranscriptsEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.LiveTranscriptsEnabled }},
+	{"recordingEnabled", `false`, func(m *VAPIAssistantResourceModel) { m.RecordingEnabled = types.BoolValue(false) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.RecordingEnabled }},
+	{"firstMessage", `""`, func(m *VAPIAssistantResourceModel) { m.FirstMessage = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.FirstMessage }},
+	{"voicemailMessage", `""`, func(m *VAPIAssistantResourceModel) { m.VoicemailMessage = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.VoicemailMessage }},
+	{"endCallMessage", `""`, func(m *VAPIAssistantResourceModel) { m.EndCallMessage = types.StringValue("") }, func(m *VAPIAssistantResourceModel) attr.Value { return m.EndCallMessage }},
+	{"maxDurationSeconds", `0`, func(m *VAPIAssistantResourceModel) { m.MaxDurationSeconds = types.Int64Value(0) }, func(m *VAPIAssistantResourceModel) attr.Value { return m.MaxDurationSeconds }},
+	{"voice.stability", `0`, func(m *VAPIAssistantResourceModel) { m.Voice = &VoiceResourceModel{Stability: types.Float64Value(0)} }, func(m *VAPIAssistantResourceModel) attr.Value { return m.Voice.Stability }},
+	{"voice.similarityBoost", `0`, func(m *VAPIAssistantResourceModel) {
+		m.Voice = &VoiceResourceModel{SimilarityBoost: types.Float64Value(0)}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.Voice.SimilarityBoost }},
+	{"model.maxTokens", `0`, func(m *VAPIAssistantResourceModel) { m.Model = &ModelResourceModel{MaxTokens: types.Int64Value(0)} }, func(m *VAPIAssistantResourceModel) attr.Value { return m.Model.MaxTokens }},
+	{"model.temperature", `0`, func(m *VAPIAssistantResourceModel) { m.Model = &ModelResourceModel{Temperature: types.Float64Value(0)} }, func(m *VAPIAssistantResourceModel) attr.Value { return m.Model.Temperature }},
+	{"model.knowledgeBase.topK", `0`, func(m *VAPIAssistantResourceModel) {
+		m.Model = &ModelResourceModel{KnowledgeBase: &KnowledgeBaseResourceModel{TopK: types.Int64Value(0)}}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.Model.KnowledgeBase.TopK }},
+	{"transcriber.model", `""`, func(m *VAPIAssistantResourceModel) {
+		m.Transcriber = &TranscriberResourceModel{Model: types.StringValue("")}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.Transcriber.Model }},
+	{"transcriber.language", `""`, func(m *VAPIAssistantResourceModel) {
+		m.Transcriber = &TranscriberResourceModel{Language: types.StringValue("")}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.Transcriber.Language }},
+	{"analysisPlan.summaryPrompt", `""`, func(m *VAPIAssistantResourceModel) {
+		m.AnalysisPlan = &AnalysisPlanResourceModel{SummaryPrompt: types.StringValue("")}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.AnalysisPlan.SummaryPrompt }},
+	{"analysisPlan.structuredDataPrompt", `""`, func(m *VAPIAssistantResourceModel) {
+		m.AnalysisPlan = &AnalysisPlanResourceModel{StructuredDataPrompt: types.StringValue("")}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.AnalysisPlan.StructuredDataPrompt }},
+	{"analysisPlan.successEvaluationPrompt", `""`, func(m *VAPIAssistantResourceModel) {
+		m.AnalysisPlan = &AnalysisPlanResourceModel{SuccessEvaluationPrompt: types.StringValue("")}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.AnalysisPlan.SuccessEvaluationPrompt }},
+	{"analysisPlan.successEvaluationRubric", `""`, func(m *VAPIAssistantResourceModel) {
+		m.AnalysisPlan = &AnalysisPlanResourceModel{SuccessEvaluationRubric: types.StringValue("")}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.AnalysisPlan.SuccessEvaluationRubric }},
+	{"startSpeakingPlan.waitSeconds", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StartSpeakingPlan = &StartSpeakingPlanResourceModel{WaitSeconds: types.Float64Value(0)}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.StartSpeakingPlan.WaitSeconds }},
+	{"startSpeakingPlan.smartEndpointingEnabled", `false`, func(m *VAPIAssistantResourceModel) {
+		m.StartSpeakingPlan = &StartSpeakingPlanResourceModel{SmartEndpointingEnabled: types.BoolValue(false)}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.StartSpeakingPlan.SmartEndpointingEnabled }},
+	{"startSpeakingPlan.transcriptionEndpointingPlan.onPunctuationSeconds", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StartSpeakingPlan = &StartSpeakingPlanResourceModel{TranscriptionEndpointingPlan: &TranscriptionEndpointingPlanResourceModel{OnPunctuationSeconds: types.Float64Value(0)}}
+	}, func(m *VAPIAssistantResourceModel) attr.Value {
+		return m.StartSpeakingPlan.TranscriptionEndpointingPlan.OnPunctuationSeconds
+	}},
+	{"startSpeakingPlan.transcriptionEndpointingPlan.onNoPunctuationSeconds", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StartSpeakingPlan = &StartSpeakingPlanResourceModel{TranscriptionEndpointingPlan: &TranscriptionEndpointingPlanResourceModel{OnNoPunctuationSeconds: types.Float64Value(0)}}
+	}, func(m *VAPIAssistantResourceModel) attr.Value {
+		return m.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNoPunctuationSeconds
+	}},
+	{"startSpeakingPlan.transcriptionEndpointingPlan.onNumberSeconds", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StartSpeakingPlan = &StartSpeakingPlanResourceModel{TranscriptionEndpointingPlan: &TranscriptionEndpointingPlanResourceModel{OnNumberSeconds: types.Float64Value(0)}}
+	}, func(m *VAPIAssistantResourceModel) attr.Value {
+		return m.StartSpeakingPlan.TranscriptionEndpointingPlan.OnNumberSeconds
+	}},
+	{"stopSpeakingPlan.numWords", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StopSpeakingPlan = &StopSpeakingPlanResourceModel{NumWords: types.Float64Value(0)}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.StopSpeakingPlan.NumWords }},
+	{"stopSpeakingPlan.voiceSeconds", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StopSpeakingPlan = &StopSpeakingPlanResourceModel{VoiceSeconds: types.Float64Value(0)}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.StopSpeakingPlan.VoiceSeconds }},
+	{"stopSpeakingPlan.backoffSeconds", `0`, func(m *VAPIAssistantResourceModel) {
+		m.StopSpeakingPlan = &StopSpeakingPlanResourceModel{BackoffSeconds: types.Float64Value(0)}
+	}, func(m *VAPIAssistantResourceModel) attr.Value { return m.StopSpeakingPlan.BackoffSeconds }},
+}
+
+func TestMapVAPIAssistantRequestScalars(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range assistantScalarCases {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			var zero VAPIAssistantResourceModel
+			tc.set(&zero)
+			if got, ok := jsonAtPath(t, mapVAPIAssistantRequest(&zero, nil), tc.path); !ok || got != tc.zero {
+				t.Fatalf("zero value: got %q (present %v), want %s", got, ok, tc.zero)
+			}
+
+			// Removing the attribute on update resets top-level fields with
+			// an explicit null; nested objects are sent whole, so the field
+			// is simply left out.
+			var removed VAPIAssistantResourceModel
+			got, ok := jsonAtPath(t, mapVAPIAssistantRequest(&removed, &zero), tc.path)
+			if !strings.Contains(tc.path, ".") {
+				if !ok || got != "null" {
+					t.Fatalf("removed on update: got %q (present %v), want null", got, ok)
+				}
+			} else if ok {
+				t.Fatalf("removed nested field: got %q, want omitted", got)
+			}
+
+			// Null on create is never sent.
+			if got, ok := jsonAtPath(t, mapVAPIAssistantRequest(&removed, nil), tc.path); ok {
+				t.Fatalf("null on create: got %q, want omitted", got)
+			}
+		})
+	}
+}
+
+func TestMapResponseObjectScalars(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range assistantScalarCases {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			var want VAPIAssistantResourceModel
+			tc.set(&want)
+
+			var present VAPIAssistantResourceModel
+			mapResponseObject(&present, assistantWithField(t, tc.path, json.RawMessage(tc.zero)))
+			if got := tc.get(&present); !got.Equal(tc.get(&want)) {
+				t.Fatalf("zero value: got %s, want %s", got, tc.get(&want))
+			}
+
+			var absent VAPIAssistantResourceModel
+			mapResponseObject(&absent, assistantWithField(t, tc.path, nil))
+			if got := tc.get(&absent); !got.IsNull() {
+				t.Fatalf("absent field: got %s, want null", got)
+			}
+		})
+	}
+}
+
+// jsonAtPath marshals v and returns the raw JSON found at the dotted path.
+func jsonAtPath(t *testing.T, v interface{}, fieldPath string) (string, bool) {
+	t.Helper()
+
+	raw := json.RawMessage(mustMarshal(t, v))
+	for _, key := range strings.Split(fieldPath, ".") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return "", false
+		}
+		next, ok := object[key]
+		if !ok {
+			return "", false
+		}
+		raw = next
+	}
+	return string(raw), true
+}
+
+// assistantWithField decodes an assistant response whose only content is
+// value at the dotted path. A nil value leaves the field out but keeps its
+// parent objects.
+func assistantWithField(t *testing.T, fieldPath string, value json.RawMessage) *vapi.Assistant {
+	t.Helper()
+
+	keys := strings.Split(fieldPath, ".")
+	var body interface{} = map[string]interface{}{}
+	if value != nil {
+		body = map[string]interface{}{keys[len(keys)-1]: value}
+	}
+	for i := len(keys) - 2; i >= 0; i-- {
+		body = map[string]interface{}{keys[i]: body}
+	}
+
+	var assistant vapi.Assistant
+	if err := json.Unmarshal(mustMarshal(t, body), &assistant); err != nil {
+		t.Fatalf("failed to decode assistant: %v", err)
+	}
+	return &assistant
+}
+
+func TestBuildModelMessages(t *testing.T) {
+	t.Parallel()
+
+	model := &ModelResourceModel{
+		SystemPrompt: types.StringValue("be brief"),
+		Messages: []ModelMessageResourceModel{
+			{Role: types.StringValue("user"), Content: types.StringValue("hi")},
+			{Role: types.StringValue("assistant"), Content: types.StringValue("hello")},
+		},
+	}
+
+	got := string(mustMarshal(t, buildModelMessages(model)))
+	want := `[{"role":"system","content":"be brief"},{"role":"user","content":"hi"},{"role":"assistant","content":"hello"}]`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if messages := buildModelMessages(&ModelResourceModel{}); messages != nil {
+		t.Fatalf("expected no messages, got %#v", messages)
+	}
+}
+
+func TestSplitModelMessages(t *testing.T) {
+	t.Parallel()
+
+	message := func(role, content string) ModelMessageResourceModel {
+		return ModelMessageResourceModel{Role: types.StringValue(role), Content: types.StringValue(content)}
+	}
+	few := []vapi.Message{
+		{Role: "system", Content: "be brief"},
+		{Role: "user", Content: "hi"},
+		{Role: "assistant", Content: "hello"},
+	}
+	configured := &ModelResourceModel{
+		SystemPrompt: types.StringValue("be brief"),
+		Messages:     []ModelMessageResourceModel{message("user", "hi"), message("assistant", "hello")},
+	}
+
+	tests := []struct {
+		name       string
+		prior      *ModelResourceModel
+		response   vapi.Model
+		wantPrompt types.String
+		want       []ModelMessageResourceModel
+	}{
+		{
+			name:       "system prompt and messages",
+			prior:      configured,
+			response:   vapi.Model{Messages: few},
+			wantPrompt: types.StringValue("be brief"),
+			want:       []ModelMessageResourceModel{message("user", "hi"), message("assistant", "hello")},
+		},
+		{
+			name:       "import",
+			response:   vapi.Model{Messages: few},
+			wantPrompt: types.StringValue("be brief"),
+			want:       []ModelMessageResourceModel{message("user", "hi"), message("assistant", "hello")},
+		},
+		{
+			name:       "system message configured in messages",
+			prior:      &ModelResourceModel{Messages: []ModelMessageResourceModel{message("system", "be brief")}},
+			response:   vapi.Model{Messages: few},
+			wantPrompt: types.StringNull(),
+			want:       []ModelMessageResourceModel{message("system", "be brief"), message("user", "hi"), message("assistant", "hello")},
+		},
+		{
+			name:       "system prompt only",
+			prior:      &ModelResourceModel{SystemPrompt: types.StringValue("be brief")},
+			response:   vapi.Model{Messages: few[:1]},
+			wantPrompt: types.StringValue("be brief"),
+		},
+		{
+			name:       "legacy systemPrompt field",
+			response:   vapi.Model{SystemPrompt: ptr("legacy")},
+			wantPrompt: types.StringValue("legacy"),
+		},
+		{
+			name:       "reordered messages are reported as drift",
+			prior:      configured,
+			response:   vapi.Model{Messages: []vapi.Message{few[0], few[2], few[1]}},
+			wantPrompt: types.StringValue("be brief"),
+			want:       []ModelMessageResourceModel{message("assistant", "hello"), message("user", "hi")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prompt, messages := splitModelMessages(tt.prior, &tt.response)
+			if !prompt.Equal(tt.wantPrompt) {
+				t.Fatalf("system prompt: got %s, want %s", prompt, tt.wantPrompt)
+			}
+			if len(messages) != len(tt.want) {
+				t.Fatalf("messages: got %v, want %v", messages, tt.want)
+			}
+			for i := range messages {
+				if !messages[i].Role.Equal(tt.want[i].Role) || !messages[i].Content.Equal(tt.want[i].Content) {
+					t.Fatalf("message %d: got %v, want %v", i, messages[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestModelToolsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tools := []ModelToolResourceModel{
+		{
+			Type:  types.StringValue("function"),
+			Async: types.BoolValue(false),
+			Function: &ModelToolFunctionModel{
+				Name:        types.StringValue("lookup_order"),
+				Description: types.StringValue("Looks up an order"),
+				Parameters: &FunctionParametersResourceModel{
+					Type: types.StringValue("object"),
+					Properties: map[string]Property{
+						"order_id": {Type: types.StringValue("string"), Description: types.StringValue("Order number"), Enum: types.ListNull(types.StringType)},
+					},
+					Required: ListValueFromStrings([]string{"order_id"}),
+				},
+			},
+			Messages: []ToolMessageResourceModel{
+				{Type: types.StringValue("request-start"), Content: types.StringValue("One moment.")},
+				{Type: types.StringValue("request-failed"), Content: types.StringValue("That did not work.")},
+			},
+			Server: &ServerResourceModel{
+				URL:            types.StringValue("https://hook.example.com/orders"),
+				Secret:         types.StringValue("s3cret"),
+				TimeoutSeconds: types.Int64Null(),
+				Headers:        types.MapNull(types.StringType),
+			},
+		},
+		{
+			Type: types.StringValue("transferCall"),
+			Destinations: []Destination{
+				{
+					Type:                   types.StringValue("number"),
+					Number:                 types.StringValue("+15550100"),
+					Extension:              types.StringNull(),
+					Message:                types.StringValue("Transferring you now."),
+					Description:            types.StringValue("Front desk"),
+					NumberE164CheckEnabled: types.BoolValue(false),
+				},
+			},
+		},
+		{
+			Type: types.StringValue("endCall"),
+		},
+	}
+
+	request := buildModelTools(tools)
+	got, ok := jsonAtPath(t, request[0], "messages")
+	if want := `[{"type":"request-start","content":"One moment."},{"type":"request-failed","content":"That did not work."}]`; !ok || got != want {
+		t.Fatalf("messages: got %s, want %s", got, want)
+	}
+	if got, _ := jsonAtPath(t, request[1], "destinations"); !strings.Contains(got, `"numberE164CheckEnabled":false`) {
+		t.Fatalf("expected explicit false E.164 check, got %s", got)
+	}
+	if got := string(mustMarshal(t, request[2])); got != `{"type":"endCall"}` {
+		t.Fatalf("endCall: got %s", got)
+	}
+
+	// The API does not return server secrets.
+	var response []vapi.ModelTool
+	if err := json.Unmarshal(mustMarshal(t, request), &response); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	response[0].Server.Secret = ""
+
+	flattened := flattenModelTools(tools, response)
+	if len(flattened) != len(tools) {
+		t.Fatalf("expected %d tools, got %d", len(tools), len(flattened))
+	}
+	if flattened[0].Server.Secret.ValueString() != "s3cret" {
+		t.Fatalf("expected secret preserved from prior state, got %s", flattened[0].Server.Secret)
+	}
+	if !flattened[0].Function.Parameters.Properties["order_id"].Description.Equal(types.StringValue("Order number")) {
+		t.Fatalf("unexpected parameters: %+v", flattened[0].Function.Parameters)
+	}
+	if len(flattened[0].Messages) != 2 || flattened[0].Messages[1].Type.ValueString() != "request-failed" {
+		t.Fatalf("unexpected messages: %+v", flattened[0].Messages)
+	}
+	if len(flattened[1].Destinations) != 1 || !flattened[1].Destinations[0].NumberE164CheckEnabled.Equal(types.BoolValue(false)) {
+		t.Fatalf("unexpected destinations: %+v", flattened[1].Destinations)
+	}
+	if flattened[2].Function != nil || flattened[2].Messages != nil || !flattened[2].Async.IsNull() {
+		t.Fatalf("expected bare endCall tool, got %+v", flattened[2])
+	}
+}
+
+func TestFallbackPlansRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	voices := &VoiceFallbackPlanResourceModel{
+		Voices: []FallbackVoiceResourceModel{
+			{
+				Provider:        types.StringValue("cartesia"),
+				VoiceID:         types.StringValue("sonic-1"),
+				Model:           types.StringNull(),
+				Stability:       types.Float64Null(),
+				SimilarityBoost: types.Float64Null(),
+			},
+			{
+				Provider:        types.StringValue("11labs"),
+				VoiceID:         types.StringValue("burt"),
+				Model:           types.StringValue("eleven_turbo_v2"),
+				Stability:       types.Float64Value(0),
+				SimilarityBoost: types.Float64Value(0.75),
+			},
+		},
+	}
+	transcribers := &TranscriberFallbackPlanResourceModel{
+		Transcribers: []FallbackTranscriberResourceModel{
+			{Provider: types.StringValue("gladia"), Model: types.StringNull(), Language: types.StringValue("en")},
+			{Provider: types.StringValue("deepgram"), Model: types.StringValue("nova-2"), Language: types.StringNull()},
+		},
+	}
+	models := []FallbackModelResourceModel{
+		{Provider: types.StringValue("anthropic"), Model: types.StringValue("claude-3-haiku")},
+		{Provider: types.StringValue("openai"), Model: types.StringValue("gpt-4o-mini")},
+	}
+
+	voicePlan := buildVoiceFallbackPlan(voices)
+	if got, want := string(mustMarshal(t, voicePlan)), `{"voices":[{"voiceId":"sonic-1","provider":"cartesia"},{"model":"eleven_turbo_v2","voiceId":"burt","provider":"11labs","stability":0,"similarityBoost":0.75}]}`; got != want {
+		t.Fatalf("voice fallback plan: got %s, want %s", got, want)
+	}
+	transcriberPlan := buildTranscriberFallbackPlan(transcribers)
+	if got, want := string(mustMarshal(t, transcriberPlan)), `{"transcribers":[{"language":"en","provider":"gladia"},{"model":"nova-2","provider":"deepgram"}]}`; got != want {
+		t.Fatalf("transcriber fallback plan: got %s, want %s", got, want)
+	}
+	fallbackModels := buildFallbackModels(models)
+	if got, want := string(mustMarshal(t, fallbackModels)), `[{"provider":"anthropic","model":"claude-3-haiku"},{"provider":"openai","model":"gpt-4o-mini"}]`; got != want {
+		t.Fatalf("fallback models: got %s, want %s", got, want)
+	}
+
+	flatVoices := flattenVoiceFallbackPlan(voicePlan)
+	for i, want := range voices.Voices {
+		got := flatVoices.Voices[i]
+		if !got.Provider.Equal(want.Provider) || !got.VoiceID.Equal(want.VoiceID) || !got.Model.Equal(want.Model) ||
+			!got.Stability.Equal(want.Stability) || !got.SimilarityBoost.Equal(want.SimilarityBoost) {
+			t.Fatalf("voice %d: got %+v, want %+v", i, got, want)
+		}
+	}
+	flatTranscribers := flattenTranscriberFallbackPlan(transcriberPlan)
+	for i, want := range transcribers.Transcribers {
+		if got := flatTranscribers.Transcribers[i]; got != want {
+			t.Fatalf("transcriber %d: got %+v, want %+v", i, got, want)
+		}
+	}
+	flatModels := flattenFallbackModels(fallbackModels)
+	for i, want := range models {
+		if got := flatModels[i]; got != want {
+			t.Fatalf("model %d: got %+v, want %+v", i, got, want)
+		}
+	}
+
+	if flattenVoiceFallbackPlan(&vapi.VoiceFallbackPlan{}) != nil || flattenTranscriberFallbackPlan(nil) != nil || flattenFallbackModels(nil) != nil {
+		t.Fatal("expected empty fallback plans to flatten to null")
+	}
+}
+
+func TestFallbackPlansProviderValidation(t *testing.T) {
+	t.Parallel()
+
+	voice := func(provider string, stability, similarityBoost types.Float64) *VoiceFallbackPlanResourceModel {
+		return &VoiceFallbackPlanResourceModel{Voices: []FallbackVoiceResourceModel{{
+			Provider:        types.StringValue(provider),
+			VoiceID:         types.StringValue("voice-1"),
+			Model:           types.StringNull(),
+			Stability:       stability,
+			SimilarityBoost: similarityBoost,
+		}}}
+	}
+	cases := map[string]struct {
+		configure func(*VAPIAssistantResourceModel)
+		wantError string
+	}{
+		"stability on 11labs": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Voice.FallbackPlan = voice("11labs", types.Float64Value(0.5), types.Float64Value(0.75))
+			},
+		},
+		"stability on cartesia": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Voice.FallbackPlan = voice("cartesia", types.Float64Value(0.5), types.Float64Null())
+			},
+			wantError: "voice.fallback_plan.voices[0].stability",
+		},
+		"similarity boost on azure": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Voice.FallbackPlan = voice("azure", types.Float64Null(), types.Float64Value(0.75))
+			},
+			wantError: "voice.fallback_plan.voices[0].similarity_boost",
+		},
+		"language on custom transcriber": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Transcriber.FallbackPlan = &TranscriberFallbackPlanResourceModel{Transcribers: []FallbackTranscriberResourceModel{
+					{Provider: types.StringValue("deepgram"), Model: types.StringValue("nova-2"), Language: types.StringValue("en")},
+					{Provider: types.StringValue("custom-transcriber"), Model: types.StringNull(), Language: types.StringValue("en")},
+				}}
+			},
+			wantError: "transcriber.fallback_plan.transcribers[1].language",
+		},
+		"custom llm fallback model": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Model.FallbackModels = []FallbackModelResourceModel{{Provider: types.StringValue("custom-llm"), Model: types.StringValue("my-model")}}
+			},
+			wantError: "model.fallback_models[0].provider",
+		},
+	}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&VAPIAssistantResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	attributes := map[string]schema.Attribute{
+		"voice":       schemaResp.Schema.Attributes["voice"],
+		"transcriber": schemaResp.Schema.Attributes["transcriber"],
+		"model":       schemaResp.Schema.Attributes["model"],
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := assistantTestModel()
+			tc.configure(&model)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := state.Set(ctx, model); diags.HasError() {
+				t.Fatalf("state.Set diagnostics: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			diags := validateAttributes(ctx, config, path.Empty(), attributes)
+			assertSingleAttributeError(t, diags, tc.wantError)
+		})
+	}
+}
+
+func TestVoiceSettingsProviderValidation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		provider  string
+		configure func(*VoiceResourceModel)
+		wantError string
+	}{
+		"style on 11labs": {
+			provider:  "11labs",
+			configure: func(v *VoiceResourceModel) { v.Style = types.Float64Value(0.4) },
+		},
+		"style on azure": {
+			provider:  "azure",
+			configure: func(v *VoiceResourceModel) { v.Style = types.Float64Value(0.4) },
+			wantError: "voice.style",
+		},
+		"style out of range": {
+			provider:  "11labs",
+			configure: func(v *VoiceResourceModel) { v.Style = types.Float64Value(1.5) },
+			wantError: "voice.style",
+		},
+		"speed on openai": {
+			provider:  "openai",
+			configure: func(v *VoiceResourceModel) { v.Speed = types.Float64Value(1.25) },
+		},
+		"speed on cartesia": {
+			provider:  "cartesia",
+			configure: func(v *VoiceResourceModel) { v.Speed = types.Float64Value(1.25) },
+			wantError: "voice.speed",
+		},
+		"emotion on playht": {
+			provider:  "playht",
+			configure: func(v *VoiceResourceModel) { v.Emotion = types.StringValue("female_happy") },
+		},
+		"emotion on deepgram": {
+			provider:  "deepgram",
+			configure: func(v *VoiceResourceModel) { v.Emotion = types.StringValue("happy") },
+			wantError: "voice.emotion",
+		},
+		"language on cartesia": {
+			provider:  "cartesia",
+			configure: func(v *VoiceResourceModel) { v.Language = types.StringValue("de") },
+		},
+		"latency on 11labs": {
+			provider:  "11labs",
+			configure: func(v *VoiceResourceModel) { v.OptimizeStreamingLatency = types.Int64Value(3) },
+		},
+		"latency on openai": {
+			provider:  "openai",
+			configure: func(v *VoiceResourceModel) { v.OptimizeStreamingLatency = types.Int64Value(3) },
+			wantError: "voice.optimize_streaming_latency",
+		},
+		"preprocessing on playht": {
+			provider:  "playht",
+			configure: func(v *VoiceResourceModel) { v.InputPreprocessingEnabled = types.BoolValue(false) },
+			wantError: "voice.input_preprocessing_enabled",
+		},
+		"chunk plan on any provider": {
+			provider: "deepgram",
+			configure: func(v *VoiceResourceModel) {
+				v.ChunkPlan = &ChunkPlanResourceModel{
+					Enabled:               types.BoolValue(true),
+					MinCharacters:         types.Int64Value(30),
+					PunctuationBoundaries: ListValueFromStrings([]string{".", "?"}),
+				}
+			},
+		},
+		"chunk plan min characters out of range": {
+			provider: "deepgram",
+			configure: func(v *VoiceResourceModel) {
+				v.ChunkPlan = &ChunkPlanResourceModel{MinCharacters: types.Int64Value(0), PunctuationBoundaries: types.ListNull(types.StringType)}
+			},
+			wantError: "voice.chunk_plan.min_characters",
+		},
+	}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&VAPIAssistantResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := assistantTestModel()
+			model.Voice.Provider = types.StringValue(tc.provider)
+			tc.configure(model.Voice)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := state.Set(ctx, model); diags.HasError() {
+				t.Fatalf("state.Set diagnostics: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			diags := validateAttributes(ctx, config, path.Root("voice"), voiceSchemaAttributes(t, schemaResp.Schema))
+			assertSingleAttributeError(t, diags, tc.wantError)
+		})
+	}
+}
+
+// assertSingleAttributeError checks that diags holds exactly one error at
+// wantPath, or none when wantPath is empty.
+func assertSingleAttributeError(t *testing.T, diags diag.Diagnostics, wantPath string) {
+	t.Helper()
+
+	if wantPath == "" {
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		return
+	}
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error for %s, got %v", wantPath, diags)
+	}
+	if errPath := diags.Errors()[0].(diag.DiagnosticWithPath).Path().String(); errPath != wantPath { //nolint:forcetypeassert // Attribute validators always report a path.
+		t.Fatalf("expected error at %s, got %s", wantPath, errPath)
+	}
+}
+
+func voiceSchemaAttributes(t *testing.T, s schema.Schema) map[string]schema.Attribute {
+	t.Helper()
+
+	voice, ok := s.Attributes["voice"].(schema.SingleNestedAttribute)
+	if !ok {
+		t.Fatalf("unexpected voice attribute %T", s.Attributes["voice"])
+	}
+	return voice.Attributes
+}
+
+// validateAttributes runs the validators of the given schema attributes and
+// of their nested single objects, as Terraform does during validate.
+func validateAttributes(ctx context.Context, config tfsdk.Config, parent path.Path, attributes map[string]schema.Attribute) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name, attribute := range attributes {
+		attributePath := parent.AtName(name)
+		switch a := attribute.(type) {
+		case schema.StringAttribute:
+			if a.CustomType != nil && len(a.Validators) == 0 {
+				// Custom types such as jsontypes.Normalized validate themselves.
+				continue
+			}
+			var value types.String
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.StringResponse{}
+				v.ValidateString(ctx, validator.StringRequest{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+		case schema.Float64Attribute:
+			var value types.Float64
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.Float64Response{}
+				v.ValidateFloat64(ctx, validator.Float64Request{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+		case schema.Int64Attribute:
+			var value types.Int64
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.Int64Response{}
+				v.ValidateInt64(ctx, validator.Int64Request{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+		case schema.BoolAttribute:
+			var value types.Bool
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.BoolResponse{}
+				v.ValidateBool(ctx, validator.BoolRequest{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+		case schema.ListAttribute:
+			var value types.List
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.ListResponse{}
+				v.ValidateList(ctx, validator.ListRequest{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+		case schema.MapAttribute:
+			var value types.Map
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.MapResponse{}
+				v.ValidateMap(ctx, validator.MapRequest{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+		case schema.SingleNestedAttribute:
+			var value types.Object
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.ObjectResponse{}
+				v.ValidateObject(ctx, validator.ObjectRequest{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+			if !value.IsNull() {
+				diags.Append(validateAttributes(ctx, config, attributePath, a.Attributes)...)
+			}
+		case schema.ListNestedAttribute:
+			var value types.List
+			diags.Append(config.GetAttribute(ctx, attributePath, &value)...)
+			for _, v := range a.Validators {
+				resp := &validator.ListResponse{}
+				v.ValidateList(ctx, validator.ListRequest{Path: attributePath, PathExpression: attributePath.Expression(), Config: config, ConfigValue: value}, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+			for i := range value.Elements() {
+				diags.Append(validateAttributes(ctx, config, attributePath.AtListIndex(i), a.NestedObject.Attributes)...)
+			}
+		}
+	}
+	return diags
+}
+
+func TestCallControlBlocksRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	payload := `{
+		"voicemailDetection": {"provider": "vapi", "backoffPlan": {"startAtSeconds": 0, "frequencySeconds": 5, "maxRetries": 6}},
+		"keypadInputPlan": {"enabled": true, "timeoutSeconds": 2.5, "delimiters": ["#", "*"]},
+		"hooks": [
+			{"on": "customer.speech.timeout", "do": [{"type": "say", "exact": "Are you still there?"}], "options": {"timeoutSeconds": 10, "triggerMaxCount": 3, "triggerResetMode": "onUserSpeech"}},
+			{"on": "call.ending", "do": [{"type": "say", "prompt": "Summarise the next steps."}, {"type": "transfer", "destination": {"type": "number", "number": "+15550100", "message": "Connecting you.", "description": "Front desk"}}]},
+			{"on": "call.ending", "do": [{"type": "transfer", "destination": {"type": "sip", "sipUri": "sip:desk@example.com", "sipHeaders": {"X-Reason": "ending"}}}]}
+		]
+	}`
+
+	var response vapi.Assistant
+	if err := json.Unmarshal([]byte(payload), &response); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var data VAPIAssistantResourceModel
+	mapResponseObject(&data, &response)
+	if data.VoicemailDetection.Provider.ValueString() != "vapi" || !data.VoicemailDetection.MachineDetectionTimeout.IsNull() {
+		t.Fatalf("unexpected voicemail detection: %+v", data.VoicemailDetection)
+	}
+	if !data.VoicemailDetection.BackoffPlan.StartAtSeconds.Equal(types.Float64Value(0)) {
+		t.Fatalf("expected zero start_at_seconds, got %s", data.VoicemailDetection.BackoffPlan.StartAtSeconds)
+	}
+	if len(data.Hooks) != 3 || data.Hooks[1].Do[1].Destination.Number.ValueString() != "+15550100" || data.Hooks[1].Options != nil {
+		t.Fatalf("unexpected hooks: %+v", data.Hooks)
+	}
+	if sip := data.Hooks[2].Do[0].Destination; sip.SipURI.ValueString() != "sip:desk@example.com" || !sip.Number.IsNull() || !sip.Message.IsNull() {
+		t.Fatalf("unexpected hooks: %+v", data.Hooks)
+	}
+
+	request := mapVAPIAssistantRequest(&data, nil)
+	for _, field := range []string{"voicemailDetection", "keypadInputPlan", "hooks"} {
+		var want, got interface{}
+		if err := json.Unmarshal([]byte(payload), &want); err != nil {
+			t.Fatalf("unmarshal payload: %v", err)
+		}
+		raw, _ := jsonAtPath(t, request, field)
+		if err := json.Unmarshal([]byte(raw), &got); err != nil {
+			t.Fatalf("unmarshal %s: %v", field, err)
+		}
+		if wantField := want.(map[string]interface{})[field]; !reflect.DeepEqual(got, wantField) { //nolint:forcetypeassert // Payload is a JSON object.
+			t.Fatalf("%s did not round-trip: got %s", field, raw)
+		}
+	}
+
+	removed := assistantTestModel()
+	cleared := mapVAPIAssistantRequest(&removed, &data)
+	created := mapVAPIAssistantRequest(&removed, nil)
+	for field, want := range map[string]string{"voicemailDetection": "null", "keypadInputPlan": "null", "hooks": "[]"} {
+		if raw, _ := jsonAtPath(t, cleared, field); raw != want {
+			t.Fatalf("expected removed %s to be sent as %s, got %q", field, want, raw)
+		}
+		if raw, ok := jsonAtPath(t, created, field); ok {
+			t.Fatalf("expected %s to be omitted on create, got %s", field, raw)
+		}
+	}
+
+	var empty VAPIAssistantResourceModel
+	mapResponseObject(&empty, &vapi.Assistant{})
+	if empty.VoicemailDetection != nil || empty.KeypadInputPlan != nil || empty.Hooks != nil {
+		t.Fatalf("expected absent blocks to read back as null, got %+v %+v %+v", empty.VoicemailDetection, empty.KeypadInputPlan, empty.Hooks)
+	}
+}
+
+func TestCallControlBlocksValidation(t *testing.T) {
+	t.Parallel()
+
+	say := func(exact, prompt types.String) HookActionResourceModel {
+		return HookActionResourceModel{Type: types.StringValue("say"), Exact: exact, Prompt: prompt}
+	}
+	cases := map[string]struct {
+		configure func(*VAPIAssistantResourceModel)
+		wantError string
+	}{
+		"twilio machine detection timeout": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.VoicemailDetection = &VoicemailDetectionResourceModel{Provider: types.StringValue("twilio"), MachineDetectionTimeout: types.Int64Value(30)}
+			},
+		},
+		"machine detection timeout on openai": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.VoicemailDetection = &VoicemailDetectionResourceModel{Provider: types.StringValue("openai"), MachineDetectionTimeout: types.Int64Value(30)}
+			},
+			wantError: "voicemail_detection.machine_detection_timeout",
+		},
+		"backoff plan on twilio": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.VoicemailDetection = &VoicemailDetectionResourceModel{
+					Provider:    types.StringValue("twilio"),
+					BackoffPlan: &VoicemailDetectionBackoffPlanResourceModel{MaxRetries: types.Int64Value(3)},
+				}
+			},
+			wantError: "voicemail_detection.backoff_plan",
+		},
+		"backoff frequency out of range": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.VoicemailDetection = &VoicemailDetectionResourceModel{
+					Provider:    types.StringValue("google"),
+					BackoffPlan: &VoicemailDetectionBackoffPlanResourceModel{FrequencySeconds: types.Float64Value(1)},
+				}
+			},
+			wantError: "voicemail_detection.backoff_plan.frequency_seconds",
+		},
+		"unknown provider": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.VoicemailDetection = &VoicemailDetectionResourceModel{Provider: types.StringValue("amd")}
+			},
+			wantError: "voicemail_detection.provider",
+		},
+		"keypad delimiters": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.KeypadInputPlan = &KeypadInputPlanResourceModel{Delimiters: ListValueFromStrings([]string{"#", "0"})}
+			},
+			wantError: "keypad_input_plan.delimiters[1]",
+		},
+		"keypad timeout out of range": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.KeypadInputPlan = &KeypadInputPlanResourceModel{TimeoutSeconds: types.Float64Value(11), Delimiters: types.ListNull(types.StringType)}
+			},
+			wantError: "keypad_input_plan.timeout_seconds",
+		},
+		"say and transfer hooks": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{
+					On: types.StringValue("call.ending"),
+					Do: []HookActionResourceModel{
+						say(types.StringValue("Goodbye."), types.StringNull()),
+						{Type: types.StringValue("transfer"), Destination: &HookDestinationResourceModel{Type: types.StringValue("number"), Number: types.StringValue("+15550100"), SipHeaders: types.MapNull(types.StringType)}},
+					},
+				}}
+			},
+		},
+		"sip hook transfer": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{
+					On: types.StringValue("customer.speech.timeout"),
+					Do: []HookActionResourceModel{{
+						Type: types.StringValue("transfer"),
+						Destination: &HookDestinationResourceModel{
+							Type:       types.StringValue("sip"),
+							SipURI:     types.StringValue("sip:desk@example.com"),
+							SipHeaders: types.MapValueMust(types.StringType, map[string]attr.Value{"X-Reason": types.StringValue("timeout")}),
+						},
+					}},
+				}}
+			},
+		},
+		"sip hook transfer without uri": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{
+					On: types.StringValue("call.ending"),
+					Do: []HookActionResourceModel{{
+						Type:        types.StringValue("transfer"),
+						Destination: &HookDestinationResourceModel{Type: types.StringValue("sip"), SipHeaders: types.MapNull(types.StringType)},
+					}},
+				}}
+			},
+			wantError: "hooks[0].do[0].destination.sip_uri",
+		},
+		"number hook transfer without number": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{
+					On: types.StringValue("call.ending"),
+					Do: []HookActionResourceModel{{
+						Type:        types.StringValue("transfer"),
+						Destination: &HookDestinationResourceModel{Type: types.StringValue("number"), SipHeaders: types.MapNull(types.StringType)},
+					}},
+				}}
+			},
+			wantError: "hooks[0].do[0].destination.number",
+		},
+		"number on sip hook transfer": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{
+					On: types.StringValue("call.ending"),
+					Do: []HookActionResourceModel{{
+						Type: types.StringValue("transfer"),
+						Destination: &HookDestinationResourceModel{
+							Type:       types.StringValue("sip"),
+							Number:     types.StringValue("+15550100"),
+							SipURI:     types.StringValue("sip:desk@example.com"),
+							SipHeaders: types.MapNull(types.StringType),
+						},
+					}},
+				}}
+			},
+			wantError: "hooks[0].do[0].destination.number",
+		},
+		"unknown hook event": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{On: types.StringValue("call.started"), Do: []HookActionResourceModel{say(types.StringValue("Hi."), types.StringNull())}}}
+			},
+			wantError: "hooks[0].on",
+		},
+		"destination on say action": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				action := say(types.StringValue("Hi."), types.StringNull())
+				action.Destination = &HookDestinationResourceModel{Type: types.StringValue("number"), Number: types.StringValue("+15550100"), SipHeaders: types.MapNull(types.StringType)}
+				m.Hooks = []HookResourceModel{{On: types.StringValue("call.ending"), Do: []HookActionResourceModel{action}}}
+			},
+			wantError: "hooks[0].do[0].destination",
+		},
+		"exact and prompt together": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{On: types.StringValue("call.ending"), Do: []HookActionResourceModel{say(types.StringValue("Hi."), types.StringValue("Greet"))}}}
+			},
+			wantError: "hooks[0].do[0].exact",
+		},
+		"trigger reset mode": {
+			configure: func(m *VAPIAssistantResourceModel) {
+				m.Hooks = []HookResourceModel{{
+					On:      types.StringValue("customer.speech.timeout"),
+					Do:      []HookActionResourceModel{say(types.StringValue("Hello?"), types.StringNull())},
+					Options: &HookOptionsResourceModel{TriggerResetMode: types.StringValue("always")},
+				}}
+			},
+			wantError: "hooks[0].options.trigger_reset_mode",
+		},
+	}
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&VAPIAssistantResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	attributes := map[string]schema.Attribute{
+		"voicemail_detection": schemaResp.Schema.Attributes["voicemail_detection"],
+		"keypad_input_plan":   schemaResp.Schema.Attributes["keypad_input_plan"],
+		"hooks":               schemaResp.Schema.Attributes["hooks"],
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := assistantTestModel()
+			tc.configure(&model)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := state.Set(ctx, model); diags.HasError() {
+				t.Fatalf("state.Set diagnostics: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			diags := validateAttributes(ctx, config, path.Empty(), attributes)
+			assertSingleAttributeError(t, diags, tc.wantError)
+		})
+	}
+}
+
+func TestStructuredDataSchemaJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// As written by jsonencode(): compact, keys sorted.
+	configured := `{"properties":{"callback":{"properties":{"requested":{"type":"boolean"},"time":{"format":"date-time","type":"string"}},"required":["requested"],"type":"object"},"items":{"items":{"properties":{"name":{"type":"string"},"quantity":{"minimum":1,"type":"integer"}},"required":["name"],"type":"object"},"type":"array"},"sentiment":{"enum":["positive","neutral","negative"],"type":"string"}},"required":["sentiment","items"],"type":"object"}`
+	data := VAPIAssistantResourceModel{
+		AnalysisPlan: &AnalysisPlanResourceModel{StructuredDataSchemaJSON: jsontypes.NewNormalizedValue(configured)},
+	}
+
+	raw, ok := jsonAtPath(t, mapVAPIAssistantRequest(&data, nil), "analysisPlan.structuredDataSchema")
+	if !ok || raw != configured {
+		t.Fatalf("schema not sent as configured: %s", raw)
+	}
+
+	// The API returns the schema formatted and in its own key order.
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(configured), "", "  "); err != nil {
+		t.Fatalf("indent: %v", err)
+	}
+	mapResponseObject(&data, &vapi.Assistant{AnalysisPlan: &vapi.AnalysisPlan{StructuredDataSchema: indented.Bytes()}})
+	if data.AnalysisPlan.StructuredDataSchema != nil {
+		t.Fatalf("expected the JSON form to be kept, got %+v", data.AnalysisPlan.StructuredDataSchema)
+	}
+	equal, diags := data.AnalysisPlan.StructuredDataSchemaJSON.StringSemanticEquals(context.Background(), jsontypes.NewNormalizedValue(configured))
+	if diags.HasError() || !equal {
+		t.Fatalf("expected read schema to equal the configured one, got %s", data.AnalysisPlan.StructuredDataSchemaJSON)
+	}
+
+	// State using the deprecated flat block keeps using it.
+	flat := VAPIAssistantResourceModel{AnalysisPlan: &AnalysisPlanResourceModel{
+		StructuredDataSchema: &StructuredDataSchemaResourceModel{
+			Type:       types.StringValue("object"),
+			Properties: map[string]PropertyResourceModel{"field": {Type: types.StringValue("string"), Description: types.StringValue("desc")}},
+		},
+	}}
+	raw, _ = jsonAtPath(t, mapVAPIAssistantRequest(&flat, nil), "analysisPlan.structuredDataSchema")
+	mapResponseObject(&flat, &vapi.Assistant{AnalysisPlan: &vapi.AnalysisPlan{StructuredDataSchema: json.RawMessage(raw)}})
+	if flat.AnalysisPlan.StructuredDataSchema == nil || !flat.AnalysisPlan.StructuredDataSchemaJSON.IsNull() ||
+		flat.AnalysisPlan.StructuredDataSchema.Properties["field"].Description.ValueString() != "desc" {
+		t.Fatalf("expected the flat form to be kept, got %+v", flat.AnalysisPlan)
+	}
+
+	mapResponseObject(&flat, &vapi.Assistant{AnalysisPlan: &vapi.AnalysisPlan{}})
+	if flat.AnalysisPlan.StructuredDataSchema != nil || !flat.AnalysisPlan.StructuredDataSchemaJSON.IsNull() {
+		t.Fatalf("expected an absent schema to read back as null, got %+v", flat.AnalysisPlan)
+	}
+}
+
+func TestAnalysisAndArtifactPlansRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	payload := `{
+		"analysisPlan": {
+			"summaryPlan": {"messages": [{"role": "system", "content": "Summarise the call."}, {"role": "user", "content": "{{transcript}}"}], "enabled": true, "timeoutSeconds": 10},
+			"structuredDataPlan": {"enabled": false, "schema": {"type": "object", "properties": {"outcome": {"type": "string", "enum": ["booked", "declined"]}}, "required": ["outcome"]}, "timeoutSeconds": 5.5},
+			"successEvaluationPlan": {"rubric": "PassFail", "messages": [{"role": "system", "content": "Was an appointment booked?"}]}
+		},
+		"artifactPlan": {
+			"recordingFormat": "wav",
+			"recordingPath": "s3://calls/recordings",
+			"videoRecordingEnabled": false,
+			"pcapEnabled": true,
+			"transcriptPlan": {"enabled": true, "assistantName": "Agent", "userName": "Caller"}
+		}
+	}`
+
+	var response vapi.Assistant
+	if err := json.Unmarshal([]byte(payload), &response); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var data VAPIAssistantResourceModel
+	mapResponseObject(&data, &response)
+	analysis := data.AnalysisPlan
+	if !analysis.SummaryPrompt.IsNull() || !analysis.StructuredDataSchemaJSON.IsNull() {
+		t.Fatalf("expected unset prompts to read back as null, got %+v", analysis)
+	}
+	if len(analysis.SummaryPlan.Messages) != 2 || !analysis.SummaryPlan.Enabled.Equal(types.BoolValue(true)) {
+		t.Fatalf("unexpected summary plan: %+v", analysis.SummaryPlan)
+	}
+	if !analysis.StructuredDataPlan.Enabled.Equal(types.BoolValue(false)) || analysis.StructuredDataPlan.Messages != nil {
+		t.Fatalf("unexpected structured data plan: %+v", analysis.StructuredDataPlan)
+	}
+	if !analysis.SuccessEvaluationPlan.Enabled.IsNull() || !analysis.SuccessEvaluationPlan.TimeoutSeconds.IsNull() {
+		t.Fatalf("expected unset success evaluation settings to read back as null, got %+v", analysis.SuccessEvaluationPlan)
+	}
+	if !data.ArtifactPlan.VideoRecordingEnabled.Equal(types.BoolValue(false)) || data.ArtifactPlan.TranscriptPlan.UserName.ValueString() != "Caller" {
+		t.Fatalf("unexpected artifact plan: %+v", data.ArtifactPlan)
+	}
+
+	request := mapVAPIAssistantRequest(&data, nil)
+	for _, field := range []string{"analysisPlan", "artifactPlan"} {
+		var want, got interface{}
+		if err := json.Unmarshal([]byte(payload), &want); err != nil {
+			t.Fatalf("unmarshal payload: %v", err)
+		}
+		raw, _ := jsonAtPath(t, request, field)
+		if err := json.Unmarshal([]byte(raw), &got); err != nil {
+			t.Fatalf("unmarshal %s: %v", field, err)
+		}
+		if wantField := want.(map[string]interface{})[field]; !reflect.DeepEqual(got, wantField) { //nolint:forcetypeassert // Payload is a JSON object.
+			t.Fatalf("%s did not round-trip: got %s", field, raw)
+		}
+	}
+
+	// Empty plans send no fields, so the API keeps its defaults.
+	empty := VAPIAssistantResourceModel{
+		AnalysisPlan: &AnalysisPlanResourceModel{SummaryPlan: &SummaryPlanResourceModel{}},
+		ArtifactPlan: &ArtifactPlanResourceModel{TranscriptPlan: &TranscriptPlanResourceModel{}},
+	}
+	request = mapVAPIAssistantRequest(&empty, nil)
+	if raw, _ := jsonAtPath(t, request, "analysisPlan"); raw != `{"summaryPlan":{}}` {
+		t.Fatalf("unexpected empty analysis plan: %s", raw)
+	}
+	if raw, _ := jsonAtPath(t, request, "artifactPlan"); raw != `{"recordingFormat":"mp3","transcriptPlan":{}}` {
+		t.Fatalf("unexpected empty artifact plan: %s", raw)
+	}
+
+	mapResponseObject(&data, &vapi.Assistant{AnalysisPlan: &vapi.AnalysisPlan{}, ArtifactPlan: &vapi.ArtifactPlan{RecordingFormat: "mp3"}})
+	if data.AnalysisPlan.SummaryPlan != nil || data.AnalysisPlan.StructuredDataPlan != nil || data.AnalysisPlan.SuccessEvaluationPlan != nil ||
+		data.ArtifactPlan.TranscriptPlan != nil || !data.ArtifactPlan.PcapEnabled.IsNull() || !data.ArtifactPlan.RecordingPath.IsNull() {
+		t.Fatalf("expected absent plans to read back as null, got %+v %+v", data.AnalysisPlan, data.ArtifactPlan)
+	}
+}
+
+// TestAssistantPlanResetsRemovedScalars plans through the provider server, so
+// removing an attribute from configuration goes through the same plan
+// modifiers and computed handling as in Terraform.
+func TestAssistantPlanResetsRemovedScalars(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	cases := map[string]func(*VAPIAssistantResourceModel){
+		"responseDelaySeconds":         func(m *VAPIAssistantResourceModel) { m.ResponseDelaySeconds = types.Float64Null() },
+		"dialKeypadFunctionEnabled":    func(m *VAPIAssistantResourceModel) { m.DialKeypadFunctionEnabled = types.BoolNull() },
+		"numWordsToInterruptAssistant": func(m *VAPIAssistantResourceModel) { m.NumWordsToInterruptAssistant = types.Int64Null() },
+		"interruptionsEnabled":         func(m *VAPIAssistantResourceModel) { m.InterruptionsEnabled = types.BoolNull() },
+		"fillersEnabled":               func(m *VAPIAssistantResourceModel) { m.FillersEnabled = types.BoolNull() },
+		"language":                     func(m *VAPIAssistantResourceModel) { m.Language = types.StringNull() },
+		"liveTranscriptsEnabled":       func(m *VAPIAssistantResourceModel) { m.LiveTranscriptsEnabled = types.BoolNull() },
+	}
+
+	var schemaResp resource.SchemaResponse
+	(&VAPIAssistantResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	priorModel := assistantTestModel()
+	priorModel.ID = types.StringValue("assistant-1")
+	priorModel.ResponseDelaySeconds = types.Float64Value(0.4)
+	priorModel.DialKeypadFunctionEnabled = types.BoolValue(true)
+	priorModel.NumWordsToInterruptAssistant = types.Int64Value(2)
+	priorModel.InterruptionsEnabled = types.BoolValue(false)
+	priorModel.FillersEnabled = types.BoolValue(true)
+	priorModel.Language = types.StringValue("en")
+	priorModel.LiveTranscriptsEnabled = types.BoolValue(true)
+	prior := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := prior.Set(ctx, &priorModel); diags.HasError() {
+		t.Fatalf("prior diagnostics: %v", diags)
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("provider server: %v", err)
+	}
+
+	for field, remove := range cases {
+		t.Run(field, func(t *testing.T) {
+			t.Parallel()
+
+			configModel := priorModel
+			remove(&configModel)
+			config := tfsdk.Config{Schema: schemaResp.Schema}
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			if diags := state.Set(ctx, &configModel); diags.HasError() {
+				t.Fatalf("config diagnostics: %v", diags)
+			}
+			config.Raw = state.Raw
+
+			// Terraform proposes the prior value for computed attributes
+			// that are not configured.
+			proposed := map[string]tftypes.Value{}
+			var configValues, priorValues map[string]tftypes.Value
+			if err := config.Raw.As(&configValues); err != nil {
+				t.Fatal(err)
+			}
+			if err := prior.Raw.As(&priorValues); err != nil {
+				t.Fatal(err)
+			}
+			for name, value := range configValues {
+				proposed[name] = value
+				if attribute := schemaResp.Schema.Attributes[name]; attribute.IsComputed() && value.IsNull() {
+					proposed[name] = priorValues[name]
+				}
+			}
+
+			dynamic := func(v tftypes.Value) *tfprotov6.DynamicValue {
+				dv, err := tfprotov6.NewDynamicValue(tfType, v)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return &dv
+			}
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "vapi_assistant",
+				PriorState:       dynamic(prior.Raw),
+				Config:           dynamic(config.Raw),
+				ProposedNewState: dynamic(tftypes.NewValue(tfType, proposed)),
+			})
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("plan diagnostics: %s: %s", d.Summary, d.Detail)
+				}
+			}
+
+			plannedRaw, err := resp.PlannedState.Unmarshal(tfType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var planned VAPIAssistantResourceModel
+			if diags := (tfsdk.Plan{Schema: schemaResp.Schema, Raw: plannedRaw}).Get(ctx, &planned); diags.HasError() {
+				t.Fatalf("planned state diagnostics: %v", diags)
+			}
+
+			if got, ok := jsonAtPath(t, mapVAPIAssistantRequest(&planned, &priorModel), field); !ok || got != "null" {
+				t.Fatalf("expected an explicit null reset, got %q (present %v)", got, ok)
+			}
+		})
+	}
+}
+
+func TestAssistantUpdateClearsRemovedPlanSettings(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	prior := assistantTestModel()
+	prior.ID = types.StringValue("assistant-1")
+	prior.AnalysisPlan = &AnalysisPlanResourceModel{
+		SummaryPrompt: types.StringValue("summary"),
+		SummaryPlan:   &SummaryPlanResourceModel{Enabled: types.BoolValue(true)},
+		SuccessEvaluationPlan: &SuccessEvaluationPlanResourceModel{
+			Rubric: types.StringValue("PassFail"),
+		},
+	}
+	prior.ArtifactPlan = &ArtifactPlanResourceModel{
+		RecordingFormat:       types.StringValue("wav"),
+		RecordingPath:         types.StringValue("s3://calls/recordings"),
+		VideoRecordingEnabled: types.BoolValue(true),
+		PcapEnabled:           types.BoolValue(true),
+		TranscriptPlan:        &TranscriptPlanResourceModel{Enabled: types.BoolValue(true)},
+	}
+
+	planned := prior
+	planned.AnalysisPlan = &AnalysisPlanResourceModel{
+		SuccessEvaluationPlan: prior.AnalysisPlan.SuccessEvaluationPlan,
+	}
+	planned.ArtifactPlan = &ArtifactPlanResourceModel{RecordingFormat: types.StringValue("wav")}
+
+	request := mapVAPIAssistantRequest(&planned, &prior)
+	if raw, _ := jsonAtPath(t, request, "analysisPlan"); raw != `{"summaryPrompt":null,"summaryPlan":null,"successEvaluationPlan":{"rubric":"PassFail"}}` {
+		t.Fatalf("unexpected analysis plan: %s", raw)
+	}
+	if raw, _ := jsonAtPath(t, request, "artifactPlan"); raw != `{"recordingFormat":"wav","recordingPath":null,"videoRecordingEnabled":null,"pcapEnabled":null,"transcriptPlan":null}` {
+		t.Fatalf("unexpected artifact plan: %s", raw)
+	}
+
+	transport := &queueRoundTripper{
+		t: t,
+		responses: []queuedResponse{
+			{method: http.MethodPatch, path: "/assistant/assistant-1", status: 200, body: []byte(`{"id":"assistant-1","name":"assistant"}`), wantBody: string(mustMarshal(t, request))},
+		},
+	}
+	res := &VAPIAssistantResource{
+		client: &vapi.APIClient{BaseURL: "https://api.example.com", Token: "token", HTTPClient: &http.Client{Transport: transport}},
+	}
+
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("state diagnostics: %v", diags)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, planned); diags.HasError() {
+		t.Fatalf("plan diagnostics: %v", diags)
+	}
+
+	updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Update(ctx, resource.UpdateRequest{State: state, Plan: plan}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	// Removing the whole plans clears them too.
+	planned.AnalysisPlan, planned.ArtifactPlan = nil, nil
+	request = mapVAPIAssistantRequest(&planned, &prior)
+	for _, field := range []string{"analysisPlan", "artifactPlan"} {
+		if raw, _ := jsonAtPath(t, request, field); raw != "null" {
+			t.Fatalf("expected removed %s to be sent as null, got %q", field, raw)
+		}
+	}
+
+	transport.assertDrained()
+}
+
+func TestMapVAPIAssistantRequestClearsRemovedBlocks(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]func(m *VAPIAssistantResourceModel){
+		// Computed lists are planned as unknown once removed from configuration.
+		"keywords":       func(m *VAPIAssistantResourceModel) { m.Keywords = types.ListUnknown(types.StringType) },
+		"clientMessages": func(m *VAPIAssistantResourceModel) { m.ClientMessages = types.ListUnknown(types.StringType) },
+		"serverMessages": func(m *VAPIAssistantResourceModel) { m.ServerMessages = types.ListUnknown(types.StringType) },
+
+		"endCallPhrases":    func(m *VAPIAssistantResourceModel) { m.EndCallPhrases = types.ListNull(types.StringType) },
+		"voice":             func(m *VAPIAssistantResourceModel) { m.Voice = nil },
+		"model":             func(m *VAPIAssistantResourceModel) { m.Model = nil },
+		"transcriber":       func(m *VAPIAssistantResourceModel) { m.Transcriber = nil },
+		"messagePlan":       func(m *VAPIAssistantResourceModel) { m.MessagePlan = nil },
+		"startSpeakingPlan": func(m *VAPIAssistantResourceModel) { m.StartSpeakingPlan = nil },
+		"stopSpeakingPlan":  func(m *VAPIAssistantResourceModel) { m.StopSpeakingPlan = nil },
+	}
+
+	for field, remove := range cases {
+		t.Run(field, func(t *testing.T) {
+			t.Parallel()
+
+			prior := assistantTestModel()
+			planned := assistantTestModel()
+			remove(&planned)
+
+			if raw, _ := jsonAtPath(t, mapVAPIAssistantRequest(&planned, &prior), field); raw != "null" {
+				t.Fatalf("expected removed %s to be sent as null, got %q", field, raw)
+			}
+			if raw, ok := jsonAtPath(t, mapVAPIAssistantRequest(&planned, nil), field); ok {
+				t.Fatalf("expected %s to be omitted on create, got %s", field, raw)
+			}
+			if raw, _ := jsonAtPath(t, mapVAPIAssistantRequest(&prior, &prior), field); raw == "null" {
+				t.Fatalf("expected a kept %s to be sent, got null", field)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -127,19 +126,13 @@ func (r *VAPISIPTrunkResource) Create(ctx context.Context, req resource.CreateRe
 
 	trunkReq := buildSIPTrunkRequest(&data)
 
-	respBytes, status, err := r.client.CreateSIPTrunk(trunkReq)
-	if err != nil || status >= 400 {
-		resp.Diagnostics.AddError("Create Failed", fmt.Sprintf("Status: %d, Error: %v", status, err))
+	created, err := r.client.CreateSIPTrunk(ctx, trunkReq)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create SIP trunk", err)
 		return
 	}
 
-	var created vapi.SIPTrunk
-	if err := json.Unmarshal(respBytes, &created); err != nil {
-		resp.Diagnostics.AddError("Unmarshal Error", err.Error())
-		return
-	}
-
-	bindVAPISIPTrunkResourceData(&data, &created)
+	bindVAPISIPTrunkResourceData(&data, created)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -150,23 +143,17 @@ func (r *VAPISIPTrunkResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	respBytes, status, err := r.client.GetSIPTrunk(data.ID.ValueString())
-	if status == 404 {
+	fetched, err := r.client.GetSIPTrunk(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-	if err != nil || status >= 400 {
-		resp.Diagnostics.AddError("Read Failed", fmt.Sprintf("Status: %d, Error: %v", status, err))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read SIP trunk", err)
 		return
 	}
 
-	var fetched vapi.SIPTrunk
-	if err := json.Unmarshal(respBytes, &fetched); err != nil {
-		resp.Diagnostics.AddWarning("Unmarshal Warning", err.Error())
-		return
-	}
-
-	bindVAPISIPTrunkResourceData(&data, &fetched)
+	bindVAPISIPTrunkResourceData(&data, fetched)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -182,19 +169,13 @@ func (r *VAPISIPTrunkResource) Update(ctx context.Context, req resource.UpdateRe
 
 	trunkReq := buildSIPTrunkRequest(&plan)
 
-	respBytes, status, err := r.client.UpdateSIPTrunk(state.ID.ValueString(), trunkReq)
-	if err != nil || status >= 400 {
-		resp.Diagnostics.AddError("Update Failed", fmt.Sprintf("Status: %d, Error: %v", status, err))
-		return
-	}
-
-	var updated vapi.SIPTrunk
-	if err := json.Unmarshal(respBytes, &updated); err != nil {
-		resp.Diagnostics.AddError("Unmarshal Error", err.Error())
+	updated, err := r.client.UpdateSIPTrunk(ctx, state.ID.ValueString(), trunkReq)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update SIP trunk", err)
 		return
 	}
 
-	bindVAPISIPTrunkResourceData(&plan, &updated)
+	bindVAPISIPTrunkResourceData(&plan, updated)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -205,9 +186,9 @@ func (r *VAPISIPTrunkResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	_, status, err := r.client.DeleteSIPTrunk(data.ID.ValueString())
-	if err != nil || status >= 400 {
-		resp.Diagnostics.AddError("Delete Failed", fmt.Sprintf("Status: %d, Error: %v", status, err))
+	err := r.client.DeleteSIPTrunk(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete SIP trunk", err)
 	}
 }
 
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -152,24 +151,13 @@ func (r *VAPIFileResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	response, responseCode, err := r.client.UploadData("file", data.Filename.ValueString(), []byte(data.Content.ValueString()))
+	fileResponse, err := r.client.UploadFile(ctx, data.Filename.ValueString(), []byte(data.Content.ValueString()))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload file: %s", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to upload file", err)
 		return
 	}
 
-	var fileResponse vapi.FileResponse
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &fileResponse); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unmarshal response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	bindVAPIFileResourceData(&data, &fileResponse)
+	bindVAPIFileResourceData(&data, fileResponse)
 
 	tflog.Trace(ctx, "created a file resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -184,32 +172,21 @@ func (r *VAPIFileResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Attempt to fetch the file details from the remote API
-	response, responseCode, err := r.client.GetFile(data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read file: %s", err))
-		return
-	}
+	fileResponse, err := r.client.GetFile(ctx, data.Id.ValueString())
 
 	// Check if the file was not found (404 or similar status code indicating missing resource)
-	if responseCode == 404 {
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	// Handle successful responses (e.g., 200 OK)
-	var fileResponse vapi.FileResponse
-	if responseCode >= 200 && responseCode < 300 {
-		// Parse the response
-		if err := json.Unmarshal(response, &fileResponse); err != nil {
-			resp.Diagnostics.AddWarning("Parse Error", fmt.Sprintf("Unable to parse file response: %s", err))
-		}
-		// Bind the file response data to the resource model
-		bindVAPIFileResourceData(&data, &fileResponse)
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read file", err)
 		return
 	}
 
+	// Bind the file response data to the resource model
+	bindVAPIFileResourceData(&data, fileResponse)
+
 	// Update the state with the latest data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -221,30 +198,20 @@ func (r *VAPIFileResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	_, _, err := r.client.DeleteFile(data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file: %s", err))
+	err := r.client.DeleteFile(ctx, data.Id.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to delete file", err)
 		return
 	}
 	bindVAPIFileResourceData(&data, &vapi.FileResponse{})
 
-	response, responseCode, err := r.client.UploadData("file", data.Filename.ValueString(), []byte(data.Content.ValueString()))
+	fileResponse, err := r.client.UploadFile(ctx, data.Filename.ValueString(), []byte(data.Content.ValueString()))
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload file: %s", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to upload file", err)
 		return
 	}
 
-	var fileResponse vapi.FileResponse
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &fileResponse); err != nil {
-			resp.Diagnostics.AddWarning("Parse Error", fmt.Sprintf("Unable to parse file response: %s", err))
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	bindVAPIFileResourceData(&data, &fileResponse)
+	bindVAPIFileResourceData(&data, fileResponse)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -256,9 +223,9 @@ func (r *VAPIFileResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	_, _, err := r.client.DeleteFile(data.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file: %s", err))
+	err := r.client.DeleteFile(ctx, data.Id.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete file", err)
 		return
 	}
 
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -30,15 +29,16 @@ type VAPISIPTrunkPhoneNumberResource struct {
 
 // VAPISIPTrunkPhoneNumberResourceModel maps the schema data.
 type VAPISIPTrunkPhoneNumberResourceModel struct {
-	ID                     types.String `tfsdk:"id"`
-	OrgID                  types.String `tfsdk:"org_id"`
-	Number                 types.String `tfsdk:"number"`
-	Name                   types.String `tfsdk:"name"`
-	PhoneProvider          types.String `tfsdk:"phone_provider"`
-	CreatedAt              types.String `tfsdk:"created_at"`
-	UpdatedAt              types.String `tfsdk:"updated_at"`
-	CredentialID           types.String `tfsdk:"credential_id"`
-	NumberE164CheckEnabled types.Bool   `tfsdk:"number_e164_check_enabled"`
+	ID                     types.String         `tfsdk:"id"`
+	OrgID                  types.String         `tfsdk:"org_id"`
+	Number                 types.String         `tfsdk:"number"`
+	Name                   types.String         `tfsdk:"name"`
+	PhoneProvider          types.String         `tfsdk:"phone_provider"`
+	CreatedAt              types.String         `tfsdk:"created_at"`
+	UpdatedAt              types.String         `tfsdk:"updated_at"`
+	CredentialID           types.String         `tfsdk:"credential_id"`
+	NumberE164CheckEnabled types.Bool           `tfsdk:"number_e164_check_enabled"`
+	Server                 *ServerResourceModel `tfsdk:"server"`
 }
 
 // Metadata sets the resource type name.
@@ -90,6 +90,7 @@ func (r *VAPISIPTrunkPhoneNumberResource) Schema(ctx context.Context, req resour
 				MarkdownDescription: "The last update timestamp.",
 				Computed:            true,
 			},
+			"server": serverAttribute("Webhook that receives events for calls to this number. Defaults to the assistant server."),
 		},
 	}
 }
@@ -126,26 +127,16 @@ func (r *VAPISIPTrunkPhoneNumberResource) Create(ctx context.Context, req resour
 		Number:                 data.Number.ValueString(),
 		CredentialID:           data.CredentialID.ValueString(),
 		NumberE164CheckEnabled: data.NumberE164CheckEnabled.ValueBool(),
+		Server:                 serverValue(buildServer(data.Server, types.StringNull(), types.StringNull()), false),
 	}
 
-	response, responseCode, err := r.client.ImportSIPTrunkPhoneNumber(requestData)
+	sipResp, err := r.client.ImportSIPTrunkPhoneNumber(ctx, requestData)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create SIP phone number: %s", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create SIP phone number", err)
 		return
 	}
 
-	if responseCode < 200 || responseCode >= 300 {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unexpected response code [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	var sipResp vapi.ImportSIPTrunkPhoneNumberResponse
-	if err := json.Unmarshal(response, &sipResp); err != nil {
-		resp.Diagnostics.AddError("Unmarshal Error", fmt.Sprintf("Failed to parse API response: %s", err))
-		return
-	}
-
-	bindSIPPhoneNumberResponse(&data, &sipResp)
+	bindSIPPhoneNumberResponse(&data, sipResp)
 	tflog.Trace(ctx, "created a SIP trunk phone number resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -158,29 +149,17 @@ func (r *VAPISIPTrunkPhoneNumberResource) Read(ctx context.Context, req resource
 		return
 	}
 
-	response, responseCode, err := r.client.GetPhoneNumber(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SIP phone number: %s", err))
-		return
-	}
-
-	if responseCode == 404 {
+	sipResp, err := r.client.GetSIPTrunkPhoneNumber(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if responseCode < 200 || responseCode >= 300 {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unexpected response code [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	var sipResp vapi.ImportSIPTrunkPhoneNumberResponse
-	if err := json.Unmarshal(response, &sipResp); err != nil {
-		resp.Diagnostics.AddError("Unmarshal Error", fmt.Sprintf("Failed to parse API response: %s", err))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read SIP phone number", err)
 		return
 	}
 
-	bindSIPPhoneNumberResponse(&data, &sipResp)
+	bindSIPPhoneNumberResponse(&data, sipResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -201,27 +180,17 @@ func (r *VAPISIPTrunkPhoneNumberResource) Update(ctx context.Context, req resour
 		Number:                 plan.Number.ValueString(),
 		CredentialID:           plan.CredentialID.ValueString(),
 		NumberE164CheckEnabled: plan.NumberE164CheckEnabled.ValueBool(),
+		Server:                 serverValue(buildServer(plan.Server, types.StringNull(), types.StringNull()), state.Server != nil),
 	}
 
-	response, responseCode, err := r.client.UpdatePhoneNumber(state.ID.ValueString(), requestData)
+	sipResp, err := r.client.UpdateSIPTrunkPhoneNumber(ctx, state.ID.ValueString(), requestData)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update SIP phone number: %s", err))
-		return
-	}
-
-	if responseCode < 200 || responseCode >= 300 {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unexpected response code [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	var sipResp vapi.ImportSIPTrunkPhoneNumberResponse
-	if err := json.Unmarshal(response, &sipResp); err != nil {
-		resp.Diagnostics.AddError("Unmarshal Error", fmt.Sprintf("Failed to parse API response: %s", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update SIP phone number", err)
 		return
 	}
 
 	data := plan
-	bindSIPPhoneNumberResponse(&data, &sipResp)
+	bindSIPPhoneNumberResponse(&data, sipResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -233,9 +202,9 @@ func (r *VAPISIPTrunkPhoneNumberResource) Delete(ctx context.Context, req resour
 		return
 	}
 
-	_, _, err := r.client.DeletePhoneNumber(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Delete Error", fmt.Sprintf("Unable to delete SIP phone number: %s", err))
+	err := r.client.DeletePhoneNumber(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete SIP phone number", err)
 		return
 	}
 
@@ -258,4 +227,5 @@ func bindSIPPhoneNumberResponse(data *VAPISIPTrunkPhoneNumberResourceModel, resp
 	data.UpdatedAt = types.StringValue(resp.UpdatedAt)
 	data.CredentialID = types.StringValue(resp.CredentialID)
 	data.NumberE164CheckEnabled = types.BoolValue(resp.NumberE164CheckEnabled)
+	data.Server = flattenServerBlock(resp.Server, data.Server)
 }
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+var _ resource.Resource = &VAPIToolBuiltinResource{}
+var _ resource.ResourceWithImportState = &VAPIToolBuiltinResource{}
+
+// NewVAPIToolEndCallResource returns the vapi_tool_end_call resource, which
+// lets the assistant hang up.
+func NewVAPIToolEndCallResource() resource.Resource {
+	return &VAPIToolBuiltinResource{
+		typeName:    "_tool_end_call",
+		toolType:    "endCall",
+		description: "Manages an `endCall` tool, which lets the assistant end the call.",
+	}
+}
+
+// NewVAPIToolDTMFResource returns the vapi_tool_dtmf resource, which lets the
+// assistant press keypad digits, for example to navigate a phone menu.
+func NewVAPIToolDTMFResource() resource.Resource {
+	return &VAPIToolBuiltinResource{
+		typeName:    "_tool_dtmf",
+		toolType:    "dtmf",
+		description: "Manages a `dtmf` tool, which lets the assistant send keypad tones, for example to navigate a phone menu.",
+	}
+}
+
+// NewVAPIToolSMSResource returns the vapi_tool_sms resource, which lets the
+// assistant send a text message to the customer.
+func NewVAPIToolSMSResource() resource.Resource {
+	return &VAPIToolBuiltinResource{
+		typeName:    "_tool_sms",
+		toolType:    "sms",
+		description: "Manages an `sms` tool, which lets the assistant send a text message to the customer.",
+	}
+}
+
+// NewVAPIToolVoicemailResource returns the vapi_tool_voicemail resource, which
+// lets the assistant detect that the call reached a voicemail box.
+func NewVAPIToolVoicemailResource() resource.Resource {
+	return &VAPIToolBuiltinResource{
+		typeName:    "_tool_voicemail",
+		toolType:    "voicemail",
+		description: "Manages a `voicemail` tool, which lets the assistant detect that the call reached voicemail and leave a message.",
+	}
+}
+
+// VAPIToolBuiltinResource manages a built-in tool that has no settings besides
+// its messages and function, such as endCall, dtmf, sms and voicemail.
+type VAPIToolBuiltinResource struct {
+	client      *vapi.APIClient
+	typeName    string
+	toolType    string
+	description string
+}
+
+type VAPIToolBuiltinResourceModel struct {
+	ID        types.String                      `tfsdk:"id"`
+	OrgID     types.String                      `tfsdk:"org_id"`
+	Function  *BuiltinToolFunctionResourceModel `tfsdk:"function"`
+	Messages  []ToolMessageResourceModel        `tfsdk:"messages"`
+	CreatedAt types.String                      `tfsdk:"created_at"`
+	UpdatedAt types.String                      `tfsdk:"updated_at"`
+}
+
+// BuiltinToolFunctionResourceModel overrides the name and description of the
+// function the model sees for a built-in tool.
+type BuiltinToolFunctionResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *VAPIToolBuiltinResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeName
+}
+
+func (r *VAPIToolBuiltinResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.description,
+		Attributes:          builtinToolAttributes(nil),
+	}
+}
+
+func (r *VAPIToolBuiltinResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vapi.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vapi.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VAPIToolBuiltinResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VAPIToolBuiltinResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := vapi.ToolRequest{
+		Type:     r.toolType,
+		Messages: toolMessagesValue(buildToolMessages(data.Messages), false),
+		Function: builtinToolFunctionValue(data.Function, nil),
+	}
+	toolResponse, err := r.client.CreateTool(ctx, request)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create "+r.toolType+" tool", err)
+		return
+	}
+
+	bindVAPIToolBuiltinResourceData(&data, toolResponse)
+
+	tflog.Trace(ctx, "created a "+r.toolType+" tool resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VAPIToolBuiltinResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VAPIToolBuiltinResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toolResponse, err := r.client.GetTool(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read "+r.toolType+" tool", err)
+		return
+	}
+
+	bindVAPIToolBuiltinResourceData(&data, toolResponse)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VAPIToolBuiltinResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan VAPIToolBuiltinResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := vapi.ToolRequest{
+		Messages: toolMessagesValue(buildToolMessages(plan.Messages), state.Messages != nil),
+		Function: builtinToolFunctionValue(plan.Function, state.Function),
+	}
+	toolResponse, err := r.client.UpdateTool(ctx, state.ID.ValueString(), request)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update "+r.toolType+" tool", err)
+		return
+	}
+
+	bindVAPIToolBuiltinResourceData(&plan, toolResponse)
+
+	tflog.Trace(ctx, "updated a "+r.toolType+" tool resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *VAPIToolBuiltinResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VAPIToolBuiltinResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTool(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete "+r.toolType+" tool", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a "+r.toolType+" tool resource")
+}
+
+func (r *VAPIToolBuiltinResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func bindVAPIToolBuiltinResourceData(data *VAPIToolBuiltinResourceModel, toolResponse *vapi.ToolResponse) {
+	data.ID = types.StringValue(toolResponse.ID)
+	data.OrgID = types.StringValue(toolResponse.OrgID)
+	data.CreatedAt = types.StringValue(toolResponse.CreatedAt)
+	data.UpdatedAt = types.StringValue(toolResponse.UpdatedAt)
+	data.Function = flattenBuiltinToolFunction(toolResponse.Function, data.Function)
+	data.Messages = flattenToolMessages(toolResponse.Messages)
+}
+
+// builtinToolAttributes returns the schema shared by the built-in tool
+// resources, merged with the type-specific attributes.
+func builtinToolAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	result := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the tool.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"org_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the organization that owns the tool.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"function": schema.SingleNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: "Overrides the name and description of the function the model sees for this tool. The API uses its own defaults when it is not set.",
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Name of the function.",
+				},
+				"description": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Tells the model when to use the tool.",
+				},
+			},
+		},
+		"messages": toolMessagesAttribute(),
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The timestamp when the tool was created.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"updated_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The timestamp when the tool was last updated.",
+		},
+	}
+	for name, attribute := range attributes {
+		result[name] = attribute
+	}
+	return result
+}
+
+// builtinToolFunctionValue returns the function sent to the API. Removing a
+// function that prior state had sends an explicit null so the API goes back
+// to its defaults.
+func builtinToolFunctionValue(function, prior *BuiltinToolFunctionResourceModel) vapi.Nullable[*vapi.Function] {
+	switch {
+	case function != nil:
+		return vapi.NewNullable(&vapi.Function{
+			Name:        function.Name.ValueString(),
+			Description: function.Description.ValueString(),
+		})
+	case prior != nil:
+		return vapi.ExplicitNull[*vapi.Function]()
+	default:
+		return vapi.Nullable[*vapi.Function]{}
+	}
+}
+
+// flattenBuiltinToolFunction maps the function from the API. The API fills in
+// a default function for tools that do not set one, which is only read back
+// when prior state has a function.
+func flattenBuiltinToolFunction(function *vapi.Function, prior *BuiltinToolFunctionResourceModel) *BuiltinToolFunctionResourceModel {
+	if function == nil || prior == nil {
+		return nil
+	}
+	return &BuiltinToolFunctionResourceModel{
+		Name:        types.StringValue(function.Name),
+		Description: stringValueOrNull(function.Description),
+	}
+}
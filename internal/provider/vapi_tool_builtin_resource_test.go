@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+func TestVAPIToolBuiltinResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		resource func() resource.Resource
+		typeName string
+		toolType string
+	}{
+		"end call":  {resource: NewVAPIToolEndCallResource, typeName: "vapi_tool_end_call", toolType: "endCall"},
+		"dtmf":      {resource: NewVAPIToolDTMFResource, typeName: "vapi_tool_dtmf", toolType: "dtmf"},
+		"sms":       {resource: NewVAPIToolSMSResource, typeName: "vapi_tool_sms", toolType: "sms"},
+		"voicemail": {resource: NewVAPIToolVoicemailResource, typeName: "vapi_tool_voicemail", toolType: "voicemail"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			created := `{"id":"tool-1","orgId":"org-1","type":"` + tc.toolType + `","createdAt":"2026-01-01T00:00:00Z","updatedAt":"2026-01-01T00:00:00Z",` +
+				`"function":{"name":"hang_up","description":"End the call when the customer says goodbye."},` +
+				`"messages":[{"type":"request-start","content":"Goodbye!"}]}`
+			updated := `{"id":"tool-1","orgId":"org-1","type":"` + tc.toolType + `","createdAt":"2026-01-01T00:00:00Z","updatedAt":"2026-02-01T00:00:00Z",` +
+				`"function":{"name":"` + tc.toolType + `","description":""}}`
+
+			transport := &queueRoundTripper{
+				t: t,
+				responses: []queuedResponse{
+					{
+						method:   http.MethodPost,
+						path:     "/tool",
+						status:   201,
+						body:     []byte(created),
+						wantBody: `{"type":"` + tc.toolType + `","messages":[{"type":"request-start","content":"Goodbye!"}],"function":{"description":"End the call when the customer says goodbye.","name":"hang_up"}}`,
+					},
+					{method: http.MethodGet, path: "/tool/tool-1", status: 200, body: []byte(created)},
+					{method: http.MethodPatch, path: "/tool/tool-1", status: 200, body: []byte(updated), wantBody: `{"messages":[],"function":null}`},
+					{method: http.MethodDelete, path: "/tool/tool-1", status: 200, body: []byte(`{}`)},
+				},
+			}
+
+			res := tc.resource()
+			metaResp := &resource.MetadataResponse{}
+			res.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "vapi"}, metaResp)
+			if metaResp.TypeName != tc.typeName {
+				t.Fatalf("expected type name %s, got %s", tc.typeName, metaResp.TypeName)
+			}
+			res.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ //nolint:forcetypeassert // Every tool resource is configurable.
+				ProviderData: &vapi.APIClient{BaseURL: "https://api.example.com", Token: "token", HTTPClient: &http.Client{Transport: transport}},
+			}, &resource.ConfigureResponse{})
+
+			var schemaResp resource.SchemaResponse
+			res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if diags := plan.Set(ctx, VAPIToolBuiltinResourceModel{
+				ID:        types.StringUnknown(),
+				OrgID:     types.StringUnknown(),
+				CreatedAt: types.StringUnknown(),
+				UpdatedAt: types.StringUnknown(),
+				Function: &BuiltinToolFunctionResourceModel{
+					Name:        types.StringValue("hang_up"),
+					Description: types.StringValue("End the call when the customer says goodbye."),
+				},
+				Messages: []ToolMessageResourceModel{{Type: types.StringValue("request-start"), Content: types.StringValue("Goodbye!"), TimingMilliseconds: types.Int64Null()}},
+			}); diags.HasError() {
+				t.Fatalf("plan diagnostics: %v", diags)
+			}
+
+			createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+			}
+
+			readResp := resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+			}
+
+			var state VAPIToolBuiltinResourceModel
+			if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("state diagnostics: %v", diags)
+			}
+			if state.ID.ValueString() != "tool-1" || state.Function.Name.ValueString() != "hang_up" || len(state.Messages) != 1 {
+				t.Fatalf("unexpected state: %+v", state)
+			}
+
+			updatePlan := tfsdk.Plan{Schema: schemaResp.Schema}
+			if diags := updatePlan.Set(ctx, VAPIToolBuiltinResourceModel{ID: state.ID, OrgID: state.OrgID, CreatedAt: state.CreatedAt, UpdatedAt: types.StringUnknown()}); diags.HasError() {
+				t.Fatalf("update plan diagnostics: %v", diags)
+			}
+
+			updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			res.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: updatePlan}, &updateResp)
+			if updateResp.Diagnostics.HasError() {
+				t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+			}
+			if diags := updateResp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("updated state diagnostics: %v", diags)
+			}
+			// The API fills in a default function, which is not read back.
+			if state.Function != nil || state.Messages != nil || state.UpdatedAt.ValueString() != "2026-02-01T00:00:00Z" {
+				t.Fatalf("unexpected updated state: %+v", state)
+			}
+
+			var deleteResp resource.DeleteResponse
+			res.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+			}
+
+			transport.assertDrained()
+		})
+	}
+}
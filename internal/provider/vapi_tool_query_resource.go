@@ -2,8 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -34,11 +32,12 @@ type KnowledgeBase struct {
 }
 
 type VAPIToolQueryFunctionResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrgID          types.String `tfsdk:"org_id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	KnowledgeBases types.List   `tfsdk:"knowledge_bases"`
+	ID             types.String               `tfsdk:"id"`
+	OrgID          types.String               `tfsdk:"org_id"`
+	Name           types.String               `tfsdk:"name"`
+	Description    types.String               `tfsdk:"description"`
+	KnowledgeBases types.List                 `tfsdk:"knowledge_bases"`
+	Messages       []ToolMessageResourceModel `tfsdk:"messages"`
 }
 
 func (r *VAPIToolQueryFunctionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -97,6 +96,7 @@ func (r *VAPIToolQueryFunctionResource) Schema(ctx context.Context, req resource
 					},
 				},
 			},
+			"messages": toolMessagesAttribute(),
 		},
 	}
 }
@@ -173,21 +173,12 @@ func (r *VAPIToolQueryFunctionResource) Create(ctx context.Context, req resource
 			Description: data.Description.ValueString(),
 		},
 		KnowledgeBases: kbs,
+		Messages:       toolMessagesValue(buildToolMessages(data.Messages), false),
 	}
 
-	resBody, status, err := r.client.CreateToolQueryFunction(request)
+	res, err := r.client.CreateToolQueryFunction(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error creating resource: %v", err))
-		return
-	}
-	if status >= 300 {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("HTTP %d: %s", status, string(resBody)))
-		return
-	}
-
-	var res vapi.ToolQueryFunctionResponse
-	if err := json.Unmarshal(resBody, &res); err != nil {
-		resp.Diagnostics.AddError("Response Error", fmt.Sprintf("Error parsing response: %v", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create query tool", err)
 		return
 	}
 
@@ -205,25 +196,20 @@ func (r *VAPIToolQueryFunctionResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	resBody, status, err := r.client.GetToolQueryFunction(data.ID.ValueString())
-	if status == 404 {
+	res, err := r.client.GetToolQueryFunction(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-	if err != nil || status >= 300 {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error reading resource: %v", err))
-		return
-	}
-
-	var res vapi.ToolQueryFunctionResponse
-	if err := json.Unmarshal(resBody, &res); err != nil {
-		resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Failed to parse response: %v", err))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read query tool", err)
 		return
 	}
 
 	data.Name = types.StringValue(res.Function.Name)
 	data.Description = types.StringValue(res.Function.Description)
 	data.OrgID = types.StringValue(res.OrgID)
+	data.Messages = flattenToolMessages(res.Messages)
 
 	var kbElements []attr.Value
 	for _, kb := range res.KnowledgeBases {
@@ -323,21 +309,12 @@ func (r *VAPIToolQueryFunctionResource) Update(ctx context.Context, req resource
 			Description: data.Description.ValueString(),
 		},
 		KnowledgeBases: kbs,
+		Messages:       toolMessagesValue(buildToolMessages(data.Messages), state.Messages != nil),
 	}
 
-	resBody, status, err := r.client.UpdateToolQueryFunction(state.ID.ValueString(), request)
+	res, err := r.client.UpdateToolQueryFunction(ctx, state.ID.ValueString(), request)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error updating resource: %v", err))
-		return
-	}
-	if status >= 300 {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("HTTP %d: %s", status, string(resBody)))
-		return
-	}
-
-	var res vapi.ToolQueryFunctionResponse
-	if err := json.Unmarshal(resBody, &res); err != nil {
-		resp.Diagnostics.AddError("Response Error", fmt.Sprintf("Error parsing update response: %v", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update query tool", err)
 		return
 	}
 
@@ -352,9 +329,9 @@ func (r *VAPIToolQueryFunctionResource) Delete(ctx context.Context, req resource
 		return
 	}
 
-	_, _, err := r.client.DeleteToolQueryFunction(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Error deleting resource: %v", err))
+	err := r.client.DeleteToolQueryFunction(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete query tool", err)
 	}
 }
 
@@ -1,23 +1,30 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+	"reflect"
+	"strings"
 )
 
 var _ resource.Resource = &VAPIToolFunctionResource{}
 var _ resource.ResourceWithImportState = &VAPIToolFunctionResource{}
+var _ resource.ResourceWithUpgradeState = &VAPIToolFunctionResource{}
 
 func NewVAPIToolFunctionResource() resource.Resource {
 	return &VAPIToolFunctionResource{}
@@ -37,18 +44,22 @@ type Destination struct {
 }
 
 type VAPIToolFunctionResourceModel struct {
-	ID           types.String  `tfsdk:"id"`
-	OrgID        types.String  `tfsdk:"org_id"`
-	Name         types.String  `tfsdk:"name"`
-	Description  types.String  `tfsdk:"description"`
-	Async        types.Bool    `tfsdk:"async"`
-	Type         types.String  `tfsdk:"type"`
-	ServerURL    types.String  `tfsdk:"server_url"`
-	ServerSecret types.String  `tfsdk:"server_secret"`
-	Parameters   Parameters    `tfsdk:"parameters"`
-	Destinations []Destination `tfsdk:"destinations"`
-	CreatedAt    types.String  `tfsdk:"created_at"`
-	UpdatedAt    types.String  `tfsdk:"updated_at"`
+	ID             types.String               `tfsdk:"id"`
+	OrgID          types.String               `tfsdk:"org_id"`
+	Name           types.String               `tfsdk:"name"`
+	Description    types.String               `tfsdk:"description"`
+	Async          types.Bool                 `tfsdk:"async"`
+	Type           types.String               `tfsdk:"type"`
+	ServerURL      types.String               `tfsdk:"server_url"`
+	ServerSecret   types.String               `tfsdk:"server_secret"`
+	Server         *ServerResourceModel       `tfsdk:"server"`
+	Strict         types.Bool                 `tfsdk:"strict"`
+	Parameters     *Parameters                `tfsdk:"parameters"`
+	ParametersJSON jsontypes.Normalized       `tfsdk:"parameters_json"`
+	Destinations   []Destination              `tfsdk:"destinations"`
+	Messages       []ToolMessageResourceModel `tfsdk:"messages"`
+	CreatedAt      types.String               `tfsdk:"created_at"`
+	UpdatedAt      types.String               `tfsdk:"updated_at"`
 }
 
 type Parameters struct {
@@ -64,6 +75,30 @@ type Property struct {
 	Enum        types.List   `tfsdk:"enum"`
 }
 
+// FunctionParametersResourceModel is the JSON schema of a function's
+// parameters, as used by tools defined inline on an assistant model.
+type FunctionParametersResourceModel struct {
+	Type       types.String        `tfsdk:"type"`
+	Properties map[string]Property `tfsdk:"properties"`
+	Required   types.List          `tfsdk:"required"`
+}
+
+// ToolMessageResourceModel is a message spoken during a tool call.
+type ToolMessageResourceModel struct {
+	Type               types.String                        `tfsdk:"type"`
+	Content            types.String                        `tfsdk:"content"`
+	Conditions         []ToolMessageConditionResourceModel `tfsdk:"conditions"`
+	TimingMilliseconds types.Int64                         `tfsdk:"timing_milliseconds"`
+}
+
+// ToolMessageConditionResourceModel restricts a tool message to calls whose
+// arguments match.
+type ToolMessageConditionResourceModel struct {
+	Param    types.String         `tfsdk:"param"`
+	Operator types.String         `tfsdk:"operator"`
+	Value    jsontypes.Normalized `tfsdk:"value"`
+}
+
 func (r *VAPIToolFunctionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_tool_function"
 }
@@ -71,45 +106,37 @@ func (r *VAPIToolFunctionResource) Metadata(ctx context.Context, req resource.Me
 func (r *VAPIToolFunctionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a function tool resource in the VAPI system.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the tool function.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"org_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The ID of the tool function.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The name of the function.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The description of the function.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"async": schema.BoolAttribute{
 				Required:            true,
 				MarkdownDescription: "Indicates whether the function is asynchronous.",
-				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
-				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The type of the tool (function).",
+				MarkdownDescription: "The type of the tool (function). Changing it replaces the tool.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -117,43 +144,44 @@ func (r *VAPIToolFunctionResource) Schema(ctx context.Context, req resource.Sche
 			"server_url": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The URL of the server where the function is hosted.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				DeprecationMessage:  "Use server.url instead.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("server")),
 				},
 			},
 			"server_secret": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
 				MarkdownDescription: "The secret used to authenticate with the server.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				DeprecationMessage:  "Use server.secret instead.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("server")),
 				},
 			},
+			"server": serverAttribute("Server where the function is hosted."),
+			"strict": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the model must follow the parameters schema exactly when calling the function. Defaults to `false`.",
+			},
 			"parameters": schema.SingleNestedAttribute{
-				MarkdownDescription: "Function parameters including type, async, and properties.",
-				Required:            true,
+				MarkdownDescription: "Function parameters including type, async, and properties. Properties cannot be nested; use `parameters_json` for nested schemas.",
+				Optional:            true,
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("parameters_json")),
+				},
 				Attributes: map[string]schema.Attribute{
 					"type": schema.StringAttribute{
 						Required:            true,
 						MarkdownDescription: "The type of parameters (object).",
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
 					},
 					"async": schema.BoolAttribute{
 						Required:            true,
 						MarkdownDescription: "Indicates whether the function parameters are async.",
-						PlanModifiers: []planmodifier.Bool{
-							boolplanmodifier.RequiresReplace(),
-						},
 					},
 					"required": schema.ListAttribute{
 						Required:            true,
 						ElementType:         types.StringType,
 						MarkdownDescription: "List of required fields.",
-						PlanModifiers: []planmodifier.List{
-							listplanmodifier.RequiresReplace(),
-						},
 					},
 					"properties": schema.MapNestedAttribute{
 						MarkdownDescription: "The properties for the function parameters.",
@@ -163,65 +191,38 @@ func (r *VAPIToolFunctionResource) Schema(ctx context.Context, req resource.Sche
 								"type": schema.StringAttribute{
 									Required:            true,
 									MarkdownDescription: "The type of the property.",
-									PlanModifiers: []planmodifier.String{
-										stringplanmodifier.RequiresReplace(),
-									},
 								},
 								"description": schema.StringAttribute{
 									Optional:            true,
 									MarkdownDescription: "A description of the property.",
-									PlanModifiers: []planmodifier.String{
-										stringplanmodifier.RequiresReplace(),
-									},
 								},
 								"enum": schema.ListAttribute{
 									Optional:            true,
 									ElementType:         types.StringType,
 									MarkdownDescription: "List of possible values for the property.",
-									PlanModifiers: []planmodifier.List{
-										listplanmodifier.RequiresReplace(),
-									},
 								},
 							},
 						},
 					},
 				},
 			},
+			"parameters_json": schema.StringAttribute{
+				Optional:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "JSON schema of the function parameters, usually written with `jsonencode()`. Supports nested `properties` and `items`, `required`, `enum`, `minimum`, `pattern`, `default` and other JSON schema keywords. Formatting and key order differences are not reported as changes.",
+			},
 			"destinations": schema.ListNestedAttribute{
 				Optional:            true,
 				MarkdownDescription: "List of destinations to forward calls.",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"type": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "The type of the destination (e.g., number).",
-						},
-						"number": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "The phone number to forward to.",
-						},
-						"extension": schema.StringAttribute{
-							Optional:            true,
-							MarkdownDescription: "The phone number extension to forward to.",
-						},
-						"message": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "Message to play before forwarding.",
-						},
-						"description": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "Description for the destination.",
-						},
-						"number_e164_check_enabled": schema.BoolAttribute{
-							Optional:            true,
-							MarkdownDescription: "Indicates whether to check the number for E.164 format.",
-						},
-					},
-				},
+				NestedObject:        destinationNestedObject(),
 			},
+			"messages": toolMessagesAttribute(),
 			"created_at": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The timestamp when the tool function was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"updated_at": schema.StringAttribute{
 				Computed:            true,
@@ -255,25 +256,18 @@ func (r *VAPIToolFunctionResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	requestBody := buildToolFunctionRequest(&data)
-	response, responseCode, err := r.client.CreateToolFunction(requestBody)
+	requestBody, err := buildToolFunctionRequest(&data)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create tool function: %s", err))
+		resp.Diagnostics.AddAttributeError(path.Root("parameters"), "Unable to encode function parameters", err.Error())
 		return
 	}
-
-	var functionResponse vapi.ToolFunctionResponse
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &functionResponse); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+	functionResponse, err := r.client.CreateToolFunction(ctx, requestBody)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create tool function", err)
 		return
 	}
 
-	bindVAPIToolFunctionResourceData(&data, &functionResponse)
+	bindVAPIToolFunctionResourceData(&data, functionResponse)
 
 	tflog.Trace(ctx, "created a tool function resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -281,35 +275,23 @@ func (r *VAPIToolFunctionResource) Create(ctx context.Context, req resource.Crea
 
 func (r *VAPIToolFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data VAPIToolFunctionResourceModel
-	var functionResponse vapi.ToolFunctionResponse
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	response, responseCode, err := r.client.GetToolFunction(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tool function: %s", err))
-		return
-	}
-
-	if responseCode == 404 {
+	functionResponse, err := r.client.GetToolFunction(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &functionResponse); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read tool function", err)
 		return
 	}
 
-	bindVAPIToolFunctionResourceData(&data, &functionResponse)
+	bindVAPIToolFunctionResourceData(&data, functionResponse)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -317,7 +299,6 @@ func (r *VAPIToolFunctionResource) Read(ctx context.Context, req resource.ReadRe
 func (r *VAPIToolFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var state VAPIToolFunctionResourceModel
 	var plan VAPIToolFunctionResourceModel
-	var functionResponse vapi.ToolFunctionResponse
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -325,24 +306,18 @@ func (r *VAPIToolFunctionResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	requestBody := buildToolFunctionRequest(&plan)
-	response, responseCode, err := r.client.UpdateToolFunction(state.ID.ValueString(), requestBody)
+	requestBody, err := buildToolFunctionUpdateRequest(&plan, &state)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update tool function: %s", err))
+		resp.Diagnostics.AddAttributeError(path.Root("parameters"), "Unable to encode function parameters", err.Error())
 		return
 	}
-
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &functionResponse); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+	functionResponse, err := r.client.UpdateToolFunction(ctx, state.ID.ValueString(), requestBody)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update tool function", err)
 		return
 	}
 
-	bindVAPIToolFunctionResourceData(&plan, &functionResponse)
+	bindVAPIToolFunctionResourceData(&plan, functionResponse)
 
 	tflog.Trace(ctx, "updated a tool function resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -355,73 +330,97 @@ func (r *VAPIToolFunctionResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	_, _, err := r.client.DeleteToolFunction(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file: %s", err))
+	err := r.client.DeleteToolFunction(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete tool function", err)
 		return
 	}
 
-	tflog.Trace(ctx, "deleted a file resource")
+	tflog.Trace(ctx, "deleted a tool function resource")
 }
 
 func (r *VAPIToolFunctionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func buildToolFunctionRequest(data *VAPIToolFunctionResourceModel) vapi.ToolFunctionRequest {
-	properties := make(map[string]vapi.Property, len(data.Parameters.Properties))
+// UpgradeState moves the deprecated server_url and server_secret of version 0
+// state into the server block.
+func (r *VAPIToolFunctionResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: serverBlockUpgrader("server_url", "server_secret"),
+	}
+}
 
-	for key, prop := range data.Parameters.Properties {
-		properties[key] = vapi.Property{
-			Type:        prop.Type.ValueString(),
-			Description: prop.Description.ValueString(),
-			Enum:        ElementsAsString(prop.Enum),
-		}
+func buildToolFunctionRequest(data *VAPIToolFunctionResourceModel) (vapi.ToolFunctionRequest, error) {
+	parameters, err := buildToolFunctionParameters(data)
+	if err != nil {
+		return vapi.ToolFunctionRequest{}, err
 	}
 
 	request := vapi.ToolFunctionRequest{
-		Type:  data.Type.ValueString(),
-		Async: data.Async.ValueBool(),
+		Type:     data.Type.ValueString(),
+		Async:    data.Async.ValueBool(),
+		Messages: buildToolMessages(data.Messages),
 		Function: vapi.Function{
 			Name:        data.Name.ValueString(),
 			Description: data.Description.ValueString(),
 			Async:       data.Async.ValueBool(),
-			Parameters: &vapi.FunctionParams{
-				Type:       data.Parameters.Type.ValueString(),
-				Properties: properties,
-				Required:   ElementsAsString(data.Parameters.Required),
-			},
+			Strict:      valuePointer(data.Strict, data.Strict.ValueBool),
+			Parameters:  parameters,
 		},
 	}
 
 	switch request.Type {
 	case "dtmf":
-		return request
+		return request, nil
 	case "transferCall":
-		var destinations []vapi.Destination
-		for _, dst := range data.Destinations {
-			destinations = append(destinations, vapi.Destination{
-				Type:                   dst.Type.ValueString(),
-				Number:                 dst.Number.ValueString(),
-				Extension:              dst.Extension.ValueString(),
-				Message:                dst.Message.ValueString(),
-				Description:            dst.Description.ValueString(),
-				NumberE164CheckEnabled: dst.NumberE164CheckEnabled.ValueBool(),
-			})
-		}
-		request.Destinations = destinations
-		return request
+		request.Destinations = buildDestinations(data.Destinations)
+		return request, nil
 	default:
 		request.Type = "function"
-		request.Server = &vapi.Server{
-			URL:            data.ServerURL.ValueString(),
-			Secret:         data.ServerSecret.ValueString(),
-			TimeoutSeconds: 20,
+		request.Server = buildServer(data.Server, data.ServerURL, data.ServerSecret)
+		return request, nil
+	}
+}
+
+// buildToolFunctionUpdateRequest returns the PATCH body for moving a tool from
+// state to plan. Only changed fields are sent; a changed function or server is
+// sent whole, and a removed server or destinations list is cleared.
+func buildToolFunctionUpdateRequest(plan, state *VAPIToolFunctionResourceModel) (vapi.UpdateToolFunctionRequest, error) {
+	var request vapi.UpdateToolFunctionRequest
+	planned, err := buildToolFunctionRequest(plan)
+	if err != nil {
+		return request, err
+	}
+	prior, err := buildToolFunctionRequest(state)
+	if err != nil {
+		return request, err
+	}
+
+	if planned.Async != prior.Async {
+		request.Async = vapi.NewNullable(planned.Async)
+	}
+	if !reflect.DeepEqual(planned.Function, prior.Function) {
+		request.Function = &planned.Function
+	}
+	if !reflect.DeepEqual(planned.Server, prior.Server) {
+		request.Server = serverValue(planned.Server, prior.Server != nil)
+	}
+	if !reflect.DeepEqual(planned.Messages, prior.Messages) {
+		request.Messages = toolMessagesValue(planned.Messages, prior.Messages != nil)
+	}
+	if !reflect.DeepEqual(planned.Destinations, prior.Destinations) {
+		if planned.Destinations == nil {
+			planned.Destinations = []vapi.Destination{}
 		}
-		return request
+		request.Destinations = vapi.NewNullable(planned.Destinations)
 	}
+	return request, nil
 }
 
+// bindVAPIToolFunctionResourceData maps a tool from the API into data, so
+// that changes made outside Terraform show up as drift. Values the API does
+// not return, such as the server secret, are kept from data.
 func bindVAPIToolFunctionResourceData(data *VAPIToolFunctionResourceModel, functionResponse *vapi.ToolFunctionResponse) {
 	data.ID = types.StringValue(functionResponse.ID)
 	data.OrgID = types.StringValue(functionResponse.OrgID)
@@ -429,32 +428,362 @@ func bindVAPIToolFunctionResourceData(data *VAPIToolFunctionResourceModel, funct
 	data.UpdatedAt = types.StringValue(functionResponse.UpdatedAt)
 	data.Type = types.StringValue(functionResponse.Type)
 	data.Async = types.BoolValue(functionResponse.Async)
-	if len(functionResponse.Server.URL) > 0 {
-		data.ServerURL = types.StringValue(functionResponse.Server.URL)
-	}
+	flattenServer(functionResponse.Server, &data.Server, &data.ServerURL, &data.ServerSecret)
 
 	data.Name = types.StringValue(functionResponse.Function.Name)
 	data.Description = types.StringValue(functionResponse.Function.Description)
-	data.Async = types.BoolValue(functionResponse.Function.Async)
+	data.Strict = flattenStrict(functionResponse.Function.Strict, data.Strict)
+
+	data.Parameters, data.ParametersJSON = flattenToolFunctionParameters(functionResponse.Function, data.Parameters)
+	data.Destinations = flattenDestinations(functionResponse.Destinations)
+	data.Messages = flattenToolMessages(functionResponse.Messages)
+}
 
-	data.Parameters = Parameters{
-		Type:       types.StringValue(functionResponse.Function.Parameters.Type),
-		Async:      types.BoolValue(functionResponse.Function.Async),
-		Required:   ListValueFromStrings(functionResponse.Function.Parameters.Required),
-		Properties: make(map[string]Property),
+// flattenStrict maps function.strict from the API. The API may report the
+// default of false for tools that never set it, which is kept as null unless
+// prior held a value.
+func flattenStrict(strict *bool, prior types.Bool) types.Bool {
+	if strict == nil || (!*strict && prior.IsNull()) {
+		return types.BoolNull()
 	}
+	return types.BoolValue(*strict)
+}
 
-	for key, prop := range functionResponse.Function.Parameters.Properties {
-		enumList, diag := types.ListValueFrom(context.Background(), types.StringType, prop.Enum)
-		if diag.HasError() {
-			// Optionally log or handle diagnostics
-			continue
-		}
+// buildToolFunctionParameters returns the parameters schema sent to the API,
+// from either parameters_json or the flat parameters block.
+func buildToolFunctionParameters(data *VAPIToolFunctionResourceModel) (json.RawMessage, error) {
+	if raw := normalizedRawMessage(data.ParametersJSON); raw != nil {
+		return raw, nil
+	}
+	if data.Parameters == nil {
+		return nil, nil
+	}
 
-		data.Parameters.Properties[key] = Property{
+	raw, err := json.Marshal(vapi.FunctionParams{
+		Type:       data.Parameters.Type.ValueString(),
+		Properties: buildProperties(data.Parameters.Properties),
+		Required:   ElementsAsString(data.Parameters.Required),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode function parameters: %w", err)
+	}
+	return raw, nil
+}
+
+// flattenToolFunctionParameters maps the parameters schema from the API into
+// the form the prior state uses: the flat parameters block when it is set and
+// can hold the schema, parameters_json otherwise. A schema with keywords the
+// flat block has no attribute for, for example one edited in the dashboard,
+// is read into parameters_json so that the change shows up as drift.
+func flattenToolFunctionParameters(function vapi.ResponseFunction, prior *Parameters) (*Parameters, jsontypes.Normalized) {
+	if len(function.Parameters) == 0 || string(function.Parameters) == "null" {
+		return nil, normalizedJSON(nil)
+	}
+
+	var params vapi.FunctionParams
+	decoder := json.NewDecoder(bytes.NewReader(function.Parameters))
+	decoder.DisallowUnknownFields()
+	if prior == nil || decoder.Decode(&params) != nil {
+		return nil, normalizedJSON(function.Parameters)
+	}
+
+	result := &Parameters{
+		Type:       types.StringValue(params.Type),
+		Async:      prior.Async,
+		Required:   ListValueFromStrings(params.Required),
+		Properties: make(map[string]Property, len(params.Properties)),
+	}
+	for key, prop := range params.Properties {
+		result.Properties[key] = Property{
 			Type:        types.StringValue(prop.Type),
-			Description: types.StringValue(prop.Description),
-			Enum:        enumList,
+			Description: stringValueOrNull(prop.Description),
+			Enum:        listValueOrNull(prop.Enum),
+		}
+	}
+	return result, normalizedJSON(nil)
+}
+
+func buildProperties(properties map[string]Property) map[string]vapi.Property {
+	result := make(map[string]vapi.Property, len(properties))
+	for key, prop := range properties {
+		result[key] = vapi.Property{
+			Type:        prop.Type.ValueString(),
+			Description: prop.Description.ValueString(),
+			Enum:        ElementsAsString(prop.Enum),
 		}
 	}
+	return result
+}
+
+// toolMessageTypes are the points in a tool call at which a message can be
+// spoken.
+var toolMessageTypes = []string{"request-start", "request-complete", "request-failed", "request-response-delayed"}
+
+// toolMessageConditionOperators compare a tool call argument with a condition
+// value.
+var toolMessageConditionOperators = []string{"eq", "neq", "gt", "gte", "lt", "lte"}
+
+func destinationNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the destination (e.g., number).",
+			},
+			"number": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The phone number to forward to.",
+			},
+			"extension": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The phone number extension to forward to.",
+			},
+			"message": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Message to play before forwarding.",
+			},
+			"description": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Description for the destination.",
+			},
+			"number_e164_check_enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Indicates whether to check the number for E.164 format.",
+			},
+		},
+	}
+}
+
+func functionParametersAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "JSON schema of the function parameters.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of parameters (object).",
+			},
+			"required": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of required fields.",
+			},
+			"properties": schema.MapNestedAttribute{
+				MarkdownDescription: "The properties for the function parameters.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The type of the property.",
+						},
+						"description": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "A description of the property.",
+						},
+						"enum": schema.ListAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "List of possible values for the property.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func toolMessagesAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Messages the assistant speaks while the tool runs.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "When the message is spoken: request-start, request-complete, request-failed or request-response-delayed.",
+					Validators: []validator.String{
+						stringvalidator.OneOf(toolMessageTypes...),
+					},
+				},
+				"content": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Content of the message.",
+				},
+				"conditions": schema.ListNestedAttribute{
+					Optional:            true,
+					MarkdownDescription: "Speak the message only when all conditions on the tool call arguments hold.",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"param": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "Name of the tool call argument.",
+							},
+							"operator": schema.StringAttribute{
+								Required:            true,
+								MarkdownDescription: "Comparison operator: " + strings.Join(toolMessageConditionOperators, ", ") + ".",
+								Validators: []validator.String{
+									stringvalidator.OneOf(toolMessageConditionOperators...),
+								},
+							},
+							"value": schema.StringAttribute{
+								Required:            true,
+								CustomType:          jsontypes.NormalizedType{},
+								MarkdownDescription: "Value the argument is compared with, as JSON so that numbers and booleans keep their type, usually via `jsonencode()`.",
+							},
+						},
+					},
+				},
+				"timing_milliseconds": schema.Int64Attribute{
+					Optional:            true,
+					MarkdownDescription: "Milliseconds without a response after which a request-response-delayed message is spoken, between 100 and 120000.",
+					Validators: []validator.Int64{
+						int64validator.Between(100, 120000),
+						supportedByTypes("request-response-delayed"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildDestinations(destinations []Destination) []vapi.Destination {
+	var result []vapi.Destination
+	for _, dst := range destinations {
+		result = append(result, vapi.Destination{
+			Type:                   dst.Type.ValueString(),
+			Number:                 dst.Number.ValueString(),
+			Extension:              dst.Extension.ValueString(),
+			Message:                dst.Message.ValueString(),
+			Description:            dst.Description.ValueString(),
+			NumberE164CheckEnabled: valuePointer(dst.NumberE164CheckEnabled, dst.NumberE164CheckEnabled.ValueBool),
+		})
+	}
+	return result
+}
+
+func flattenDestinations(destinations []vapi.Destination) []Destination {
+	if len(destinations) == 0 {
+		return nil
+	}
+
+	result := make([]Destination, 0, len(destinations))
+	for _, dst := range destinations {
+		result = append(result, Destination{
+			Type:                   types.StringValue(dst.Type),
+			Number:                 types.StringValue(dst.Number),
+			Extension:              stringValueOrNull(dst.Extension),
+			Message:                types.StringValue(dst.Message),
+			Description:            types.StringValue(dst.Description),
+			NumberE164CheckEnabled: types.BoolPointerValue(dst.NumberE164CheckEnabled),
+		})
+	}
+	return result
+}
+
+func buildFunctionParameters(params *FunctionParametersResourceModel) json.RawMessage {
+	if params == nil {
+		return nil
+	}
+
+	raw, _ := json.Marshal(vapi.FunctionParams{
+		Type:       params.Type.ValueString(),
+		Properties: buildProperties(params.Properties),
+		Required:   ElementsAsString(params.Required),
+	})
+	return raw
+}
+
+func flattenFunctionParameters(raw json.RawMessage) *FunctionParametersResourceModel {
+	var params vapi.FunctionParams
+	if len(raw) == 0 || string(raw) == "null" || json.Unmarshal(raw, &params) != nil {
+		return nil
+	}
+
+	model := &FunctionParametersResourceModel{
+		Type:     types.StringValue(params.Type),
+		Required: types.ListNull(types.StringType),
+	}
+	if len(params.Required) > 0 {
+		model.Required = ListValueFromStrings(params.Required)
+	}
+	if len(params.Properties) > 0 {
+		model.Properties = make(map[string]Property, len(params.Properties))
+		for key, prop := range params.Properties {
+			enum := types.ListNull(types.StringType)
+			if len(prop.Enum) > 0 {
+				enum = ListValueFromStrings(prop.Enum)
+			}
+			model.Properties[key] = Property{
+				Type:        types.StringValue(prop.Type),
+				Description: stringValueOrNull(prop.Description),
+				Enum:        enum,
+			}
+		}
+	}
+	return model
+}
+
+func buildToolMessages(messages []ToolMessageResourceModel) []vapi.ToolMessage {
+	var result []vapi.ToolMessage
+	for _, message := range messages {
+		result = append(result, vapi.ToolMessage{
+			Type:               message.Type.ValueString(),
+			Content:            message.Content.ValueString(),
+			Conditions:         buildToolMessageConditions(message.Conditions),
+			TimingMilliseconds: valuePointer(message.TimingMilliseconds, message.TimingMilliseconds.ValueInt64),
+		})
+	}
+	return result
+}
+
+func buildToolMessageConditions(conditions []ToolMessageConditionResourceModel) []vapi.ToolMessageCondition {
+	var result []vapi.ToolMessageCondition
+	for _, condition := range conditions {
+		result = append(result, vapi.ToolMessageCondition{
+			Param:    condition.Param.ValueString(),
+			Operator: condition.Operator.ValueString(),
+			Value:    normalizedRawMessage(condition.Value),
+		})
+	}
+	return result
+}
+
+// toolMessagesValue wraps messages for a request body. Removing every message
+// that prior state had sends an empty list so the API clears them.
+func toolMessagesValue(messages []vapi.ToolMessage, hadMessages bool) vapi.Nullable[[]vapi.ToolMessage] {
+	return sliceValue(messages, hadMessages)
+}
+
+func flattenToolMessages(messages []vapi.ToolMessage) []ToolMessageResourceModel {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	result := make([]ToolMessageResourceModel, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, ToolMessageResourceModel{
+			Type:               types.StringValue(message.Type),
+			Content:            stringValueOrNull(message.Content),
+			Conditions:         flattenToolMessageConditions(message.Conditions),
+			TimingMilliseconds: types.Int64PointerValue(message.TimingMilliseconds),
+		})
+	}
+	return result
+}
+
+func flattenToolMessageConditions(conditions []vapi.ToolMessageCondition) []ToolMessageConditionResourceModel {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	result := make([]ToolMessageConditionResourceModel, 0, len(conditions))
+	for _, condition := range conditions {
+		result = append(result, ToolMessageConditionResourceModel{
+			Param:    types.StringValue(condition.Param),
+			Operator: types.StringValue(condition.Operator),
+			Value:    normalizedJSON(condition.Value),
+		})
+	}
+	return result
 }
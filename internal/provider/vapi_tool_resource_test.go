@@ -1,13 +1,21 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
 )
@@ -24,20 +32,20 @@ func TestVAPIToolFunctionResourceLifecycle(t *testing.T) {
 		UpdatedAt: "2024-01-01T00:00:00Z",
 		Type:      "function",
 		Async:     true,
-		Server: vapi.ResponseServer{
+		Server: &vapi.Server{
 			URL: "https://server.example.com",
 		},
 		Function: vapi.ResponseFunction{
 			Name:        "tool",
 			Description: "desc",
 			Async:       true,
-			Parameters: vapi.ResponseFunctionParams{
+			Parameters: mustMarshal(t, vapi.FunctionParams{
 				Type:     "object",
 				Required: []string{"foo"},
-				Properties: map[string]vapi.ResponseProperty{
+				Properties: map[string]vapi.Property{
 					"foo": {Description: "A field", Type: "string", Enum: []string{"opt1"}},
 				},
-			},
+			}),
 		},
 	})
 
@@ -48,20 +56,20 @@ func TestVAPIToolFunctionResourceLifecycle(t *testing.T) {
 		UpdatedAt: "2024-01-02T00:00:00Z",
 		Type:      "function",
 		Async:     true,
-		Server: vapi.ResponseServer{
+		Server: &vapi.Server{
 			URL: "https://server.example.com/updated",
 		},
 		Function: vapi.ResponseFunction{
 			Name:        "tool-updated",
 			Description: "desc",
 			Async:       true,
-			Parameters: vapi.ResponseFunctionParams{
+			Parameters: mustMarshal(t, vapi.FunctionParams{
 				Type:     "object",
 				Required: []string{"foo"},
-				Properties: map[string]vapi.ResponseProperty{
+				Properties: map[string]vapi.Property{
 					"foo": {Description: "A field", Type: "string", Enum: []string{"opt1"}},
 				},
-			},
+			}),
 		},
 	})
 
@@ -193,7 +201,7 @@ func TestVAPIToolFunctionResourceCreateVariants(t *testing.T) {
 				Function: vapi.ResponseFunction{
 					Name:        tc.model.Name.ValueString(),
 					Description: tc.model.Description.ValueString(),
-					Parameters:  vapi.ResponseFunctionParams{Type: "object"},
+					Parameters:  json.RawMessage(`{"type":"object"}`),
 				},
 			})
 
@@ -239,7 +247,7 @@ func toolFunctionModel(name, serverURL string) VAPIToolFunctionResourceModel {
 		Type:         types.StringValue("function"),
 		ServerURL:    types.StringValue(serverURL),
 		ServerSecret: types.StringValue("secret"),
-		Parameters: Parameters{
+		Parameters: &Parameters{
 			Type:     types.StringValue("object"),
 			Async:    types.BoolValue(true),
 			Required: ListValueFromStrings([]string{"foo"}),
@@ -253,3 +261,377 @@ func toolFunctionModel(name, serverURL string) VAPIToolFunctionResourceModel {
 		},
 	}
 }
+
+func TestBuildToolFunctionUpdateRequest(t *testing.T) {
+	t.Parallel()
+
+	function := `"function":{"description":"desc","async":true,"name":"%s","parameters":{"type":"object","properties":{"foo":{"type":"string","description":"A field","enum":["opt1"]}},"required":["foo"]}}`
+	cases := []struct {
+		name   string
+		change func(m *VAPIToolFunctionResourceModel)
+		want   string
+	}{
+		{
+			name:   "unchanged",
+			change: func(m *VAPIToolFunctionResourceModel) {},
+			want:   `{}`,
+		},
+		{
+			name:   "renamed",
+			change: func(m *VAPIToolFunctionResourceModel) { m.Name = types.StringValue("renamed") },
+			want:   "{" + fmt.Sprintf(function, "renamed") + "}",
+		},
+		{
+			name:   "server moved",
+			change: func(m *VAPIToolFunctionResourceModel) { m.ServerURL = types.StringValue("https://moved.example.com") },
+			want:   `{"server":{"url":"https://moved.example.com","secret":"secret"}}`,
+		},
+		{
+			name: "server block with the same url",
+			change: func(m *VAPIToolFunctionResourceModel) {
+				m.Server = &ServerResourceModel{URL: m.ServerURL, Secret: m.ServerSecret}
+				m.ServerURL, m.ServerSecret = types.StringNull(), types.StringNull()
+			},
+			want: `{}`,
+		},
+		{
+			name: "server removed",
+			change: func(m *VAPIToolFunctionResourceModel) {
+				m.ServerURL, m.ServerSecret = types.StringNull(), types.StringNull()
+			},
+			want: `{"server":null}`,
+		},
+		{
+			name:   "async",
+			change: func(m *VAPIToolFunctionResourceModel) { m.Async = types.BoolValue(false) },
+			want:   `{"function":{"description":"desc","name":"tool","parameters":{"type":"object","properties":{"foo":{"type":"string","description":"A field","enum":["opt1"]}},"required":["foo"]}},"async":false}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := toolFunctionModel("tool", "https://server.example.com")
+			plan := toolFunctionModel("tool", "https://server.example.com")
+			tc.change(&plan)
+
+			if got := string(mustMarshal(t, mustBuildToolFunctionUpdateRequest(t, &plan, &state))); got != tc.want {
+				t.Fatalf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	state := toolFunctionModel("transfer", "")
+	state.Type = types.StringValue("transferCall")
+	state.Destinations = []Destination{{Type: types.StringValue("number"), Number: types.StringValue("+123")}}
+	plan := state
+	plan.Destinations = nil
+	if got := string(mustMarshal(t, mustBuildToolFunctionUpdateRequest(t, &plan, &state))); got != `{"destinations":[]}` {
+		t.Fatalf("expected removed destinations to be cleared, got %s", got)
+	}
+}
+
+func TestToolFunctionParametersJSON(t *testing.T) {
+	t.Parallel()
+
+	// As written by jsonencode(): compact, keys sorted.
+	configured := `{"properties":{"address":{"properties":{"city":{"type":"string"},"postcode":{"pattern":"^[0-9]{5}$","type":"string"}},"required":["city"],"type":"object"},"items":{"items":{"properties":{"quantity":{"default":1,"minimum":1,"type":"integer"},"sku":{"type":"string"}},"required":["sku"],"type":"object"},"type":"array"}},"required":["items"],"type":"object"}`
+
+	model := toolFunctionModel("crm-lookup", "https://crm.example.com")
+	model.Parameters = nil
+	model.ParametersJSON = jsontypes.NewNormalizedValue(configured)
+
+	raw, ok := jsonAtPath(t, mustBuildToolFunctionRequest(t, &model), "function.parameters")
+	if !ok || raw != configured {
+		t.Fatalf("parameters not sent as configured: %s", raw)
+	}
+
+	// The API returns the schema formatted and in its own key order.
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(configured), "", "  "); err != nil {
+		t.Fatalf("indent: %v", err)
+	}
+	bindVAPIToolFunctionResourceData(&model, &vapi.ToolFunctionResponse{
+		Type:     "function",
+		Function: vapi.ResponseFunction{Name: "crm-lookup", Parameters: indented.Bytes()},
+	})
+	if model.Parameters != nil {
+		t.Fatalf("expected the JSON form to be kept, got %+v", model.Parameters)
+	}
+	equal, diags := model.ParametersJSON.StringSemanticEquals(context.Background(), jsontypes.NewNormalizedValue(configured))
+	if diags.HasError() || !equal {
+		t.Fatalf("expected read parameters to equal the configured ones, got %s", model.ParametersJSON)
+	}
+
+	// Unchanged JSON parameters are not sent on update.
+	state := model
+	state.ParametersJSON = jsontypes.NewNormalizedValue(configured)
+	if got := string(mustMarshal(t, mustBuildToolFunctionUpdateRequest(t, &state, &state))); got != `{}` {
+		t.Fatalf("expected an empty update, got %s", got)
+	}
+
+	// Imported tools use the JSON form.
+	var imported VAPIToolFunctionResourceModel
+	bindVAPIToolFunctionResourceData(&imported, &vapi.ToolFunctionResponse{
+		Type:     "function",
+		Function: vapi.ResponseFunction{Parameters: json.RawMessage(`{"type":"object","properties":{"id":{"type":"string"}}}`)},
+	})
+	if imported.Parameters != nil || imported.ParametersJSON.IsNull() {
+		t.Fatalf("expected imported parameters as JSON, got %+v / %s", imported.Parameters, imported.ParametersJSON)
+	}
+}
+
+func TestBindToolFunctionRecordedPayloads(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		prior   func() VAPIToolFunctionResourceModel
+		payload string
+		check   func(t *testing.T, got VAPIToolFunctionResourceModel)
+	}{
+		"edited in the dashboard": {
+			prior: func() VAPIToolFunctionResourceModel {
+				model := toolFunctionModel("lookup", "")
+				model.ServerURL, model.ServerSecret = types.StringNull(), types.StringNull()
+				model.Server = &ServerResourceModel{URL: types.StringValue("https://tools.example.com"), Secret: types.StringValue("s3cret")}
+				return model
+			},
+			payload: `{
+				"id": "tool-1",
+				"orgId": "org-1",
+				"type": "function",
+				"async": false,
+				"createdAt": "2026-01-01T00:00:00Z",
+				"updatedAt": "2026-02-01T00:00:00Z",
+				"server": {"url": "https://tools.example.com/v2", "timeoutSeconds": 45, "headers": {"X-Team": "support"}},
+				"function": {
+					"name": "lookup",
+					"description": "Look up a customer",
+					"async": true,
+					"strict": true,
+					"parameters": {"type": "object", "properties": {"foo": {"type": "string", "enum": ["opt1", "opt2"], "minLength": 2}}, "required": ["foo"]}
+				}
+			}`,
+			check: func(t *testing.T, got VAPIToolFunctionResourceModel) {
+				if got.Async.ValueBool() || !got.Strict.ValueBool() || got.Description.ValueString() != "Look up a customer" {
+					t.Fatalf("unexpected function attributes: async %s strict %s description %s", got.Async, got.Strict, got.Description)
+				}
+				if got.Server == nil || got.Server.URL.ValueString() != "https://tools.example.com/v2" || got.Server.Secret.ValueString() != "s3cret" ||
+					got.Server.TimeoutSeconds.ValueInt64() != 45 || len(got.Server.Headers.Elements()) != 1 {
+					t.Fatalf("unexpected server: %+v", got.Server)
+				}
+				// minLength has no attribute in the flat block, so the schema is read into parameters_json.
+				if got.Parameters != nil || !strings.Contains(got.ParametersJSON.ValueString(), `"minLength"`) {
+					t.Fatalf("expected parameters_json, got %+v / %s", got.Parameters, got.ParametersJSON)
+				}
+			},
+		},
+		"flat parameters": {
+			prior: func() VAPIToolFunctionResourceModel { return toolFunctionModel("lookup", "https://tools.example.com") },
+			payload: `{
+				"id": "tool-1",
+				"type": "function",
+				"async": true,
+				"server": {"url": "https://tools.example.com"},
+				"function": {
+					"name": "lookup",
+					"description": "desc",
+					"strict": false,
+					"parameters": {"type": "object", "properties": {"foo": {"type": "string", "description": "A field", "enum": ["opt1", "opt3"]}, "bar": {"type": "number"}}, "required": ["foo"]}
+				}
+			}`,
+			check: func(t *testing.T, got VAPIToolFunctionResourceModel) {
+				if got.ServerURL.ValueString() != "https://tools.example.com" || got.ServerSecret.ValueString() != "secret" || got.Server != nil {
+					t.Fatalf("expected the deprecated server attributes to be kept, got %s %s %+v", got.ServerURL, got.ServerSecret, got.Server)
+				}
+				if !got.Strict.IsNull() || got.Parameters == nil || !got.Parameters.Async.ValueBool() {
+					t.Fatalf("unexpected strict or parameters: %s %+v", got.Strict, got.Parameters)
+				}
+				if enum := ElementsAsString(got.Parameters.Properties["foo"].Enum); fmt.Sprint(enum) != "[opt1 opt3]" {
+					t.Fatalf("unexpected enum: %v", enum)
+				}
+				if bar := got.Parameters.Properties["bar"]; !bar.Description.IsNull() || !bar.Enum.IsNull() {
+					t.Fatalf("expected unset property attributes to be null, got %+v", bar)
+				}
+			},
+		},
+		"transfer destinations": {
+			prior: func() VAPIToolFunctionResourceModel {
+				return VAPIToolFunctionResourceModel{Type: types.StringValue("transferCall"), Parameters: &Parameters{}}
+			},
+			payload: `{
+				"id": "tool-2",
+				"type": "transferCall",
+				"async": false,
+				"function": {"name": "transfer", "description": ""},
+				"destinations": [
+					{"type": "number", "number": "+15551234567", "message": "Transferring you now", "description": "Support line", "numberE164CheckEnabled": false},
+					{"type": "number", "number": "+15557654321", "extension": "42", "message": "", "description": "Billing"}
+				]
+			}`,
+			check: func(t *testing.T, got VAPIToolFunctionResourceModel) {
+				if len(got.Destinations) != 2 || got.Destinations[0].NumberE164CheckEnabled.ValueBool() ||
+					got.Destinations[1].Extension.ValueString() != "42" || !got.Destinations[0].Extension.IsNull() {
+					t.Fatalf("unexpected destinations: %+v", got.Destinations)
+				}
+				if got.Server != nil || got.Parameters != nil || !got.ParametersJSON.IsNull() {
+					t.Fatalf("expected no server or parameters, got %+v %+v", got.Server, got.Parameters)
+				}
+			},
+		},
+		"removed outside Terraform": {
+			prior: func() VAPIToolFunctionResourceModel {
+				model := toolFunctionModel("lookup", "https://tools.example.com")
+				model.Strict = types.BoolValue(true)
+				model.Destinations = []Destination{{Type: types.StringValue("number")}}
+				model.Messages = []ToolMessageResourceModel{{Type: types.StringValue("request-start")}}
+				return model
+			},
+			payload: `{"id": "tool-1", "type": "function", "async": true, "function": {"name": "lookup", "description": "desc", "strict": false}}`,
+			check: func(t *testing.T, got VAPIToolFunctionResourceModel) {
+				if !got.ServerURL.IsNull() || got.Strict.ValueBool() || got.Strict.IsNull() {
+					t.Fatalf("expected server removal and strict=false to be read back, got %s %s", got.ServerURL, got.Strict)
+				}
+				if got.Parameters != nil || got.Destinations != nil || got.Messages != nil {
+					t.Fatalf("expected removed blocks to read back as null, got %+v %+v %+v", got.Parameters, got.Destinations, got.Messages)
+				}
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var response vapi.ToolFunctionResponse
+			if err := json.Unmarshal([]byte(tc.payload), &response); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			got := tc.prior()
+			bindVAPIToolFunctionResourceData(&got, &response)
+			tc.check(t, got)
+		})
+	}
+}
+
+func TestToolMessagesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	payload := `{
+		"id": "tool-1",
+		"type": "function",
+		"function": {"name": "book"},
+		"messages": [
+			{"type": "request-start", "content": "Let me check that for you.", "conditions": [{"param": "nights", "operator": "gt", "value": 3}, {"param": "budget", "operator": "lt", "value": 1000000}, {"param": "vip", "operator": "eq", "value": true}, {"param": "city", "operator": "neq", "value": "Paris"}]},
+			{"type": "request-response-delayed", "content": "Still working on it.", "timingMilliseconds": 2000},
+			{"type": "request-failed"}
+		]
+	}`
+
+	var response vapi.ToolFunctionResponse
+	if err := json.Unmarshal([]byte(payload), &response); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var state VAPIToolFunctionResourceModel
+	bindVAPIToolFunctionResourceData(&state, &response)
+	if len(state.Messages) != 3 || state.Messages[1].TimingMilliseconds.ValueInt64() != 2000 || !state.Messages[2].Content.IsNull() {
+		t.Fatalf("unexpected messages: %+v", state.Messages)
+	}
+	for i, want := range []string{`3`, `1000000`, `true`, `"Paris"`} {
+		if got := state.Messages[0].Conditions[i].Value.ValueString(); got != want {
+			t.Fatalf("condition %d: got value %s, want %s", i, got, want)
+		}
+	}
+
+	want := `[{"type":"request-start","content":"Let me check that for you.","conditions":[{"param":"nights","operator":"gt","value":3},{"param":"budget","operator":"lt","value":1000000},{"param":"vip","operator":"eq","value":true},{"param":"city","operator":"neq","value":"Paris"}]},{"type":"request-response-delayed","content":"Still working on it.","timingMilliseconds":2000},{"type":"request-failed"}]`
+	if got, _ := jsonAtPath(t, mustBuildToolFunctionRequest(t, &state), "messages"); got != want {
+		t.Fatalf("unexpected request messages: %s", got)
+	}
+
+	plan := state
+	plan.Messages = nil
+	if got := string(mustMarshal(t, mustBuildToolFunctionUpdateRequest(t, &plan, &state))); got != `{"messages":[]}` {
+		t.Fatalf("expected removed messages to be cleared, got %s", got)
+	}
+	if got := string(mustMarshal(t, mustBuildToolFunctionUpdateRequest(t, &state, &state))); got != `{}` {
+		t.Fatalf("expected unchanged messages to be omitted, got %s", got)
+	}
+}
+
+func TestToolMessagesValidation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		message   ToolMessageResourceModel
+		wantError string
+	}{
+		"delayed message timing": {
+			message: ToolMessageResourceModel{Type: types.StringValue("request-response-delayed"), TimingMilliseconds: types.Int64Value(1500)},
+		},
+		"timing on start message": {
+			message:   ToolMessageResourceModel{Type: types.StringValue("request-start"), TimingMilliseconds: types.Int64Value(1500)},
+			wantError: "messages[0].timing_milliseconds",
+		},
+		"timing out of range": {
+			message:   ToolMessageResourceModel{Type: types.StringValue("request-response-delayed"), TimingMilliseconds: types.Int64Value(50)},
+			wantError: "messages[0].timing_milliseconds",
+		},
+		"unknown type": {
+			message:   ToolMessageResourceModel{Type: types.StringValue("request-queued")},
+			wantError: "messages[0].type",
+		},
+		"unknown operator": {
+			message: ToolMessageResourceModel{
+				Type:       types.StringValue("request-complete"),
+				Conditions: []ToolMessageConditionResourceModel{{Param: types.StringValue("status"), Operator: types.StringValue("contains"), Value: jsontypes.NewNormalizedValue(`"ok"`)}},
+			},
+			wantError: "messages[0].conditions[0].operator",
+		},
+	}
+
+	ctx := context.Background()
+	resources := map[string]resource.Resource{
+		"vapi_tool_function":       &VAPIToolFunctionResource{},
+		"vapi_tool_query_function": &VAPIToolQueryFunctionResource{},
+	}
+	for resourceName, res := range resources {
+		var schemaResp resource.SchemaResponse
+		res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		attributes := map[string]schema.Attribute{"messages": schemaResp.Schema.Attributes["messages"]}
+
+		for name, tc := range cases {
+			t.Run(resourceName+"/"+name, func(t *testing.T) {
+				t.Parallel()
+
+				state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+				if diags := state.SetAttribute(ctx, path.Root("messages"), []ToolMessageResourceModel{tc.message}); diags.HasError() {
+					t.Fatalf("state.SetAttribute diagnostics: %v", diags)
+				}
+				config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+				assertSingleAttributeError(t, validateAttributes(ctx, config, path.Empty(), attributes), tc.wantError)
+			})
+		}
+	}
+}
+
+func mustBuildToolFunctionRequest(t *testing.T, data *VAPIToolFunctionResourceModel) vapi.ToolFunctionRequest {
+	t.Helper()
+	request, err := buildToolFunctionRequest(data)
+	if err != nil {
+		t.Fatalf("buildToolFunctionRequest: %v", err)
+	}
+	return request
+}
+
+func mustBuildToolFunctionUpdateRequest(t *testing.T, plan, state *VAPIToolFunctionResourceModel) vapi.UpdateToolFunctionRequest {
+	t.Helper()
+	request, err := buildToolFunctionUpdateRequest(plan, state)
+	if err != nil {
+		t.Fatalf("buildToolFunctionUpdateRequest: %v", err)
+	}
+	return request
+}
@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+var _ resource.Resource = &VAPIToolTransferCallResource{}
+var _ resource.ResourceWithImportState = &VAPIToolTransferCallResource{}
+var _ resource.ResourceWithValidateConfig = &VAPIToolTransferCallResource{}
+
+func NewVAPIToolTransferCallResource() resource.Resource {
+	return &VAPIToolTransferCallResource{}
+}
+
+// VAPIToolTransferCallResource manages a transferCall tool, which forwards
+// the call to a phone number, a SIP URI or another assistant.
+type VAPIToolTransferCallResource struct {
+	client *vapi.APIClient
+}
+
+type VAPIToolTransferCallResourceModel struct {
+	ID           types.String                       `tfsdk:"id"`
+	OrgID        types.String                       `tfsdk:"org_id"`
+	Function     *BuiltinToolFunctionResourceModel  `tfsdk:"function"`
+	Destinations []TransferDestinationResourceModel `tfsdk:"destinations"`
+	Messages     []ToolMessageResourceModel         `tfsdk:"messages"`
+	CreatedAt    types.String                       `tfsdk:"created_at"`
+	UpdatedAt    types.String                       `tfsdk:"updated_at"`
+}
+
+type TransferDestinationResourceModel struct {
+	Type                   types.String               `tfsdk:"type"`
+	Number                 types.String               `tfsdk:"number"`
+	Extension              types.String               `tfsdk:"extension"`
+	CallerID               types.String               `tfsdk:"caller_id"`
+	NumberE164CheckEnabled types.Bool                 `tfsdk:"number_e164_check_enabled"`
+	SipURI                 types.String               `tfsdk:"sip_uri"`
+	SipHeaders             types.Map                  `tfsdk:"sip_headers"`
+	AssistantName          types.String               `tfsdk:"assistant_name"`
+	TransferMode           types.String               `tfsdk:"transfer_mode"`
+	Message                types.String               `tfsdk:"message"`
+	Description            types.String               `tfsdk:"description"`
+	TransferPlan           *TransferPlanResourceModel `tfsdk:"transfer_plan"`
+}
+
+type TransferPlanResourceModel struct {
+	Mode        types.String              `tfsdk:"mode"`
+	Message     types.String              `tfsdk:"message"`
+	SipVerb     types.String              `tfsdk:"sip_verb"`
+	SummaryPlan *SummaryPlanResourceModel `tfsdk:"summary_plan"`
+}
+
+// Transfer plan modes, and the ones that say a message or a summary of the
+// call to the operator.
+var (
+	transferPlanModes = []string{
+		"blind-transfer",
+		"blind-transfer-add-summary-to-sip-header",
+		"warm-transfer-say-message",
+		"warm-transfer-say-summary",
+		"warm-transfer-wait-for-operator-to-speak-first-and-then-say-message",
+		"warm-transfer-wait-for-operator-to-speak-first-and-then-say-summary",
+		"warm-transfer-experimental",
+	}
+	transferPlanMessageModes = []string{
+		"warm-transfer-say-message",
+		"warm-transfer-wait-for-operator-to-speak-first-and-then-say-message",
+		"warm-transfer-experimental",
+	}
+	transferPlanSummaryModes = []string{
+		"blind-transfer-add-summary-to-sip-header",
+		"warm-transfer-say-summary",
+		"warm-transfer-wait-for-operator-to-speak-first-and-then-say-summary",
+		"warm-transfer-experimental",
+	}
+)
+
+// assistantTransferModes control what happens to the conversation history
+// when the call moves to another assistant.
+var assistantTransferModes = []string{
+	"rolling-history",
+	"swap-system-message-in-history",
+	"swap-system-message-in-history-and-remove-transfer-tool-messages",
+	"delete-history",
+}
+
+func (r *VAPIToolTransferCallResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_transfer_call"
+}
+
+func (r *VAPIToolTransferCallResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	summaryPlan := analysisSubPlanAttribute("Generation of the call summary said to the operator or added to the SIP header. Only supported by the summary modes.", nil)
+	summaryPlan.Validators = []validator.Object{
+		siblingValueValidator{sibling: "mode", values: transferPlanSummaryModes},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a `transferCall` tool, which lets the assistant forward the call to a phone number, a SIP URI or another assistant.",
+		Attributes: builtinToolAttributes(map[string]schema.Attribute{
+			"destinations": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Destinations the assistant can transfer the call to.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Type of the destination: `number`, `sip` or `assistant`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("number", "sip", "assistant"),
+							},
+						},
+						"number": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Phone number to transfer to. Required for `number` destinations.",
+							Validators: []validator.String{
+								supportedByTypes("number"),
+							},
+						},
+						"extension": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Extension to dial after the call is answered.",
+							Validators: []validator.String{
+								supportedByTypes("number"),
+							},
+						},
+						"caller_id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Caller ID shown to the destination, for example `{{customer.number}}`.",
+							Validators: []validator.String{
+								supportedByTypes("number"),
+							},
+						},
+						"number_e164_check_enabled": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Whether the number must be in E.164 format. The API enables it by default.",
+							Validators: []validator.Bool{
+								supportedByTypes("number"),
+							},
+						},
+						"sip_uri": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "SIP URI to transfer to. Required for `sip` destinations.",
+							Validators: []validator.String{
+								supportedByTypes("sip"),
+							},
+						},
+						"sip_headers": schema.MapAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Headers added to the SIP `REFER` or `INVITE`.",
+							Validators: []validator.Map{
+								supportedByTypes("sip"),
+							},
+						},
+						"assistant_name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Name of the assistant to transfer to, within the same squad. Required for `assistant` destinations.",
+							Validators: []validator.String{
+								supportedByTypes("assistant"),
+							},
+						},
+						"transfer_mode": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "What happens to the conversation history when the assistant takes over: `rolling-history`, `swap-system-message-in-history`, `swap-system-message-in-history-and-remove-transfer-tool-messages` or `delete-history`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(assistantTransferModes...),
+								supportedByTypes("assistant"),
+							},
+						},
+						"message": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Message said to the customer before the transfer.",
+						},
+						"description": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Tells the model when to pick this destination.",
+						},
+						"transfer_plan": schema.SingleNestedAttribute{
+							Optional:            true,
+							MarkdownDescription: "How the call is handed over. Only supported by `number` and `sip` destinations.",
+							Validators: []validator.Object{
+								supportedByTypes("number", "sip"),
+							},
+							Attributes: map[string]schema.Attribute{
+								"mode": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Transfer mode, for example `blind-transfer`, `warm-transfer-say-message` or `warm-transfer-say-summary`.",
+									Validators: []validator.String{
+										stringvalidator.OneOf(transferPlanModes...),
+									},
+								},
+								"message": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "Message said to the operator before the customer is connected. Only supported by the say-message modes.",
+									Validators: []validator.String{
+										siblingValueValidator{sibling: "mode", values: transferPlanMessageModes},
+									},
+								},
+								"sip_verb": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "SIP method used for the transfer: `refer`, `bye` or `dial`. Defaults to `refer`.",
+									Validators: []validator.String{
+										stringvalidator.OneOf("refer", "bye", "dial"),
+									},
+								},
+								"summary_plan": summaryPlan,
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+// ValidateConfig requires the address attribute that matches each
+// destination type.
+func (r *VAPIToolTransferCallResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var destinations types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("destinations"), &destinations)...)
+	if resp.Diagnostics.HasError() || destinations.IsNull() || destinations.IsUnknown() {
+		return
+	}
+
+	required := map[string]string{"number": "number", "sip": "sip_uri", "assistant": "assistant_name"}
+	for i := range destinations.Elements() {
+		destinationPath := path.Root("destinations").AtListIndex(i)
+
+		var destinationType types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, destinationPath.AtName("type"), &destinationType)...)
+		attribute, ok := required[destinationType.ValueString()]
+		if !ok {
+			continue
+		}
+
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, destinationPath.AtName(attribute), &value)...)
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				destinationPath.AtName(attribute),
+				"Missing required attribute",
+				fmt.Sprintf("Attribute %s is required for %s destinations.", destinationPath.AtName(attribute), destinationType.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *VAPIToolTransferCallResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*vapi.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *vapi.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VAPIToolTransferCallResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VAPIToolTransferCallResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := buildToolTransferCallRequest(&data, nil)
+	request.Type = "transferCall"
+	toolResponse, err := r.client.CreateTool(ctx, request)
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create transferCall tool", err)
+		return
+	}
+
+	bindVAPIToolTransferCallResourceData(&data, toolResponse)
+
+	tflog.Trace(ctx, "created a transferCall tool resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VAPIToolTransferCallResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VAPIToolTransferCallResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toolResponse, err := r.client.GetTool(ctx, data.ID.ValueString())
+	if vapi.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read transferCall tool", err)
+		return
+	}
+
+	bindVAPIToolTransferCallResourceData(&data, toolResponse)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VAPIToolTransferCallResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan VAPIToolTransferCallResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toolResponse, err := r.client.UpdateTool(ctx, state.ID.ValueString(), buildToolTransferCallRequest(&plan, &state))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update transferCall tool", err)
+		return
+	}
+
+	bindVAPIToolTransferCallResourceData(&plan, toolResponse)
+
+	tflog.Trace(ctx, "updated a transferCall tool resource")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *VAPIToolTransferCallResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VAPIToolTransferCallResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTool(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete transferCall tool", err)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a transferCall tool resource")
+}
+
+func (r *VAPIToolTransferCallResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildToolTransferCallRequest returns the request body for data. With a
+// prior state, removed destinations, messages and function are cleared.
+func buildToolTransferCallRequest(data, prior *VAPIToolTransferCallResourceModel) vapi.ToolRequest {
+	if prior == nil {
+		prior = &VAPIToolTransferCallResourceModel{}
+	}
+
+	request := vapi.ToolRequest{
+		Messages: toolMessagesValue(buildToolMessages(data.Messages), prior.Messages != nil),
+		Function: builtinToolFunctionValue(data.Function, prior.Function),
+	}
+	switch destinations := buildTransferDestinations(data.Destinations); {
+	case destinations != nil:
+		request.Destinations = vapi.NewNullable(destinations)
+	case prior.Destinations != nil:
+		request.Destinations = vapi.NewNullable([]vapi.Destination{})
+	}
+	return request
+}
+
+func bindVAPIToolTransferCallResourceData(data *VAPIToolTransferCallResourceModel, toolResponse *vapi.ToolResponse) {
+	data.ID = types.StringValue(toolResponse.ID)
+	data.OrgID = types.StringValue(toolResponse.OrgID)
+	data.CreatedAt = types.StringValue(toolResponse.CreatedAt)
+	data.UpdatedAt = types.StringValue(toolResponse.UpdatedAt)
+	data.Function = flattenBuiltinToolFunction(toolResponse.Function, data.Function)
+	data.Destinations = flattenTransferDestinations(toolResponse.Destinations)
+	data.Messages = flattenToolMessages(toolResponse.Messages)
+}
+
+func buildTransferDestinations(destinations []TransferDestinationResourceModel) []vapi.Destination {
+	var result []vapi.Destination
+	for _, dst := range destinations {
+		destination := vapi.Destination{
+			Type:                   dst.Type.ValueString(),
+			Number:                 dst.Number.ValueString(),
+			Extension:              dst.Extension.ValueString(),
+			CallerID:               dst.CallerID.ValueString(),
+			NumberE164CheckEnabled: valuePointer(dst.NumberE164CheckEnabled, dst.NumberE164CheckEnabled.ValueBool),
+			SipURI:                 dst.SipURI.ValueString(),
+			AssistantName:          dst.AssistantName.ValueString(),
+			TransferMode:           dst.TransferMode.ValueString(),
+			Message:                dst.Message.ValueString(),
+			Description:            dst.Description.ValueString(),
+		}
+		if !dst.SipHeaders.IsNull() && !dst.SipHeaders.IsUnknown() {
+			destination.SipHeaders = make(map[string]string, len(dst.SipHeaders.Elements()))
+			for name, value := range dst.SipHeaders.Elements() {
+				if str, ok := value.(types.String); ok {
+					destination.SipHeaders[name] = str.ValueString()
+				}
+			}
+		}
+		if plan := dst.TransferPlan; plan != nil {
+			destination.TransferPlan = &vapi.TransferPlan{
+				Mode:        plan.Mode.ValueString(),
+				Message:     plan.Message.ValueString(),
+				SipVerb:     plan.SipVerb.ValueString(),
+				SummaryPlan: buildSummaryPlan(plan.SummaryPlan),
+			}
+		}
+		result = append(result, destination)
+	}
+	return result
+}
+
+func flattenTransferDestinations(destinations []vapi.Destination) []TransferDestinationResourceModel {
+	if len(destinations) == 0 {
+		return nil
+	}
+
+	result := make([]TransferDestinationResourceModel, 0, len(destinations))
+	for _, dst := range destinations {
+		destination := TransferDestinationResourceModel{
+			Type:                   types.StringValue(dst.Type),
+			Number:                 stringValueOrNull(dst.Number),
+			Extension:              stringValueOrNull(dst.Extension),
+			CallerID:               stringValueOrNull(dst.CallerID),
+			NumberE164CheckEnabled: types.BoolPointerValue(dst.NumberE164CheckEnabled),
+			SipURI:                 stringValueOrNull(dst.SipURI),
+			SipHeaders:             types.MapNull(types.StringType),
+			AssistantName:          stringValueOrNull(dst.AssistantName),
+			TransferMode:           stringValueOrNull(dst.TransferMode),
+			Message:                stringValueOrNull(dst.Message),
+			Description:            stringValueOrNull(dst.Description),
+		}
+		if len(dst.SipHeaders) > 0 {
+			destination.SipHeaders, _ = types.MapValueFrom(context.Background(), types.StringType, dst.SipHeaders)
+		}
+		if plan := dst.TransferPlan; plan != nil {
+			destination.TransferPlan = &TransferPlanResourceModel{
+				Mode:        types.StringValue(plan.Mode),
+				Message:     stringValueOrNull(plan.Message),
+				SipVerb:     stringValueOrNull(plan.SipVerb),
+				SummaryPlan: flattenSummaryPlan(plan.SummaryPlan),
+			}
+		}
+		result = append(result, destination)
+	}
+	return result
+}
@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/kirillve/terraform-provider-vapi/internal/vapi"
+)
+
+// transferCallPayload is a transferCall tool as recorded from the API, with
+// one destination of each type.
+const transferCallPayload = `{
+	"id": "tool-1",
+	"orgId": "org-1",
+	"type": "transferCall",
+	"createdAt": "2026-01-01T00:00:00Z",
+	"updatedAt": "2026-01-01T00:00:00Z",
+	"function": {"name": "transferCall", "description": ""},
+	"destinations": [
+		{
+			"type": "number",
+			"number": "+15551234567",
+			"callerId": "{{customer.number}}",
+			"numberE164CheckEnabled": false,
+			"message": "Connecting you to support.",
+			"description": "Support line",
+			"transferPlan": {
+				"mode": "warm-transfer-say-summary",
+				"summaryPlan": {"enabled": true, "timeoutSeconds": 10, "messages": [{"role": "system", "content": "Summarise the call for the operator."}]}
+			}
+		},
+		{
+			"type": "sip",
+			"sipUri": "sip:billing@example.com",
+			"sipHeaders": {"X-Team": "billing"},
+			"transferPlan": {"mode": "blind-transfer", "sipVerb": "refer"}
+		},
+		{"type": "assistant", "assistantName": "Sales", "transferMode": "rolling-history"}
+	]
+}`
+
+func transferCallModel() VAPIToolTransferCallResourceModel {
+	return VAPIToolTransferCallResourceModel{
+		ID:        types.StringUnknown(),
+		OrgID:     types.StringUnknown(),
+		CreatedAt: types.StringUnknown(),
+		UpdatedAt: types.StringUnknown(),
+		Destinations: []TransferDestinationResourceModel{
+			{
+				Type:                   types.StringValue("number"),
+				Number:                 types.StringValue("+15551234567"),
+				CallerID:               types.StringValue("{{customer.number}}"),
+				NumberE164CheckEnabled: types.BoolValue(false),
+				Message:                types.StringValue("Connecting you to support."),
+				Description:            types.StringValue("Support line"),
+				SipHeaders:             types.MapNull(types.StringType),
+				TransferPlan: &TransferPlanResourceModel{
+					Mode: types.StringValue("warm-transfer-say-summary"),
+					SummaryPlan: &SummaryPlanResourceModel{
+						Enabled:        types.BoolValue(true),
+						TimeoutSeconds: types.Float64Value(10),
+						Messages:       []ModelMessageResourceModel{{Role: types.StringValue("system"), Content: types.StringValue("Summarise the call for the operator.")}},
+					},
+				},
+			},
+			{
+				Type:         types.StringValue("sip"),
+				SipURI:       types.StringValue("sip:billing@example.com"),
+				SipHeaders:   types.MapValueMust(types.StringType, map[string]attr.Value{"X-Team": types.StringValue("billing")}),
+				TransferPlan: &TransferPlanResourceModel{Mode: types.StringValue("blind-transfer"), SipVerb: types.StringValue("refer")},
+			},
+			{
+				Type:          types.StringValue("assistant"),
+				AssistantName: types.StringValue("Sales"),
+				TransferMode:  types.StringValue("rolling-history"),
+				SipHeaders:    types.MapNull(types.StringType),
+			},
+		},
+	}
+}
+
+func TestVAPIToolTransferCallResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	updated := `{"id":"tool-1","orgId":"org-1","type":"transferCall","createdAt":"2026-01-01T00:00:00Z","updatedAt":"2026-02-01T00:00:00Z"}`
+	transport := &queueRoundTripper{
+		t: t,
+		responses: []queuedResponse{
+			{method: http.MethodPost, path: "/tool", status: 201, body: []byte(transferCallPayload)},
+			{method: http.MethodGet, path: "/tool/tool-1", status: 200, body: []byte(transferCallPayload)},
+			{method: http.MethodPatch, path: "/tool/tool-1", status: 200, body: []byte(updated), wantBody: `{"destinations":[]}`},
+			{method: http.MethodDelete, path: "/tool/tool-1", status: 200, body: []byte(`{}`)},
+		},
+	}
+
+	res := &VAPIToolTransferCallResource{
+		client: &vapi.APIClient{
+			BaseURL:    "https://api.example.com",
+			Token:      "token",
+			HTTPClient: &http.Client{Transport: transport},
+		},
+	}
+
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, transferCallModel()); diags.HasError() {
+		t.Fatalf("plan diagnostics: %v", diags)
+	}
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+
+	var state VAPIToolTransferCallResourceModel
+	if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("state diagnostics: %v", diags)
+	}
+	if state.Function != nil || len(state.Destinations) != 3 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if summary := state.Destinations[0].TransferPlan.SummaryPlan; summary == nil || !summary.Enabled.ValueBool() || len(summary.Messages) != 1 {
+		t.Fatalf("unexpected warm-transfer summary plan: %+v", summary)
+	}
+	if sip := state.Destinations[1]; sip.SipHeaders.Elements()["X-Team"].(types.String).ValueString() != "billing" || !sip.Number.IsNull() { //nolint:forcetypeassert // sip_headers is a map of strings.
+		t.Fatalf("unexpected sip destination: %+v", sip)
+	}
+
+	updatePlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := updatePlan.Set(ctx, VAPIToolTransferCallResourceModel{ID: state.ID, OrgID: state.OrgID, CreatedAt: state.CreatedAt, UpdatedAt: types.StringUnknown()}); diags.HasError() {
+		t.Fatalf("update plan diagnostics: %v", diags)
+	}
+
+	updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	res.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: updatePlan}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	var deleteResp resource.DeleteResponse
+	res.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	transport.assertDrained()
+}
+
+func TestBuildToolTransferCallRequest(t *testing.T) {
+	t.Parallel()
+
+	model := transferCallModel()
+	request := buildToolTransferCallRequest(&model, nil)
+	request.Type = "transferCall"
+
+	var recorded, built any
+	if err := json.Unmarshal([]byte(transferCallPayload), &recorded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := json.Unmarshal(mustMarshal(t, request), &built); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got, want := string(mustMarshal(t, built.(map[string]any)["destinations"])), string(mustMarshal(t, recorded.(map[string]any)["destinations"])); got != want { //nolint:forcetypeassert // Both payloads are JSON objects.
+		t.Fatalf("destinations: got %s, want %s", got, want)
+	}
+	if _, ok := built.(map[string]any)["function"]; ok { //nolint:forcetypeassert // The request is a JSON object.
+		t.Fatal("expected the function to be omitted when it is not configured")
+	}
+
+	prior := model
+	prior.Function = &BuiltinToolFunctionResourceModel{Name: types.StringValue("transfer")}
+	prior.Messages = []ToolMessageResourceModel{{Type: types.StringValue("request-start")}}
+	if got := string(mustMarshal(t, buildToolTransferCallRequest(&VAPIToolTransferCallResourceModel{}, &prior))); got != `{"messages":[],"function":null,"destinations":[]}` {
+		t.Fatalf("expected removed settings to be cleared, got %s", got)
+	}
+}
+
+func TestToolTransferCallValidation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		destination func(*TransferDestinationResourceModel)
+		wantError   string
+	}{
+		"valid": {
+			destination: func(*TransferDestinationResourceModel) {},
+		},
+		"number destination without number": {
+			destination: func(d *TransferDestinationResourceModel) { d.Number = types.StringNull() },
+			wantError:   "destinations[0].number",
+		},
+		"sip uri on number destination": {
+			destination: func(d *TransferDestinationResourceModel) { d.SipURI = types.StringValue("sip:a@example.com") },
+			wantError:   "destinations[0].sip_uri",
+		},
+		"sip headers on number destination": {
+			destination: func(d *TransferDestinationResourceModel) {
+				d.SipHeaders = types.MapValueMust(types.StringType, map[string]attr.Value{"X-Team": types.StringValue("billing")})
+			},
+			wantError: "destinations[0].sip_headers",
+		},
+		"summary plan on blind transfer": {
+			destination: func(d *TransferDestinationResourceModel) { d.TransferPlan.Mode = types.StringValue("blind-transfer") },
+			wantError:   "destinations[0].transfer_plan.summary_plan",
+		},
+		"message on summary transfer": {
+			destination: func(d *TransferDestinationResourceModel) { d.TransferPlan.Message = types.StringValue("Hi") },
+			wantError:   "destinations[0].transfer_plan.message",
+		},
+		"transfer plan on assistant destination": {
+			destination: func(d *TransferDestinationResourceModel) {
+				d.Type, d.Number, d.CallerID, d.NumberE164CheckEnabled = types.StringValue("assistant"), types.StringNull(), types.StringNull(), types.BoolNull()
+				d.AssistantName = types.StringValue("Sales")
+			},
+			wantError: "destinations[0].transfer_plan",
+		},
+		"unknown transfer mode": {
+			destination: func(d *TransferDestinationResourceModel) {
+				d.TransferPlan.Mode = types.StringValue("cold-transfer")
+				d.TransferPlan.SummaryPlan = nil
+			},
+			wantError: "destinations[0].transfer_plan.mode",
+		},
+	}
+
+	ctx := context.Background()
+	res := &VAPIToolTransferCallResource{}
+	var schemaResp resource.SchemaResponse
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			destination := transferCallModel().Destinations[0]
+			tc.destination(&destination)
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.SetAttribute(ctx, path.Root("destinations"), []TransferDestinationResourceModel{destination}); diags.HasError() {
+				t.Fatalf("state.SetAttribute diagnostics: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			diags := validateAttributes(ctx, config, path.Empty(), schemaResp.Schema.Attributes)
+			validateResp := &resource.ValidateConfigResponse{}
+			res.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, validateResp)
+			diags.Append(validateResp.Diagnostics...)
+
+			assertSingleAttributeError(t, diags, tc.wantError)
+		})
+	}
+}
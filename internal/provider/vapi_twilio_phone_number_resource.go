@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -29,22 +28,23 @@ type VAPITwilioPhoneNumberResource struct {
 
 // VAPITwilioPhoneNumberResourceModel struct.
 type VAPITwilioPhoneNumberResourceModel struct {
-	ID                       types.String `tfsdk:"id"`
-	OrgID                    types.String `tfsdk:"org_id"`
-	Number                   types.String `tfsdk:"number"`
-	CreatedAt                types.String `tfsdk:"created_at"`
-	UpdatedAt                types.String `tfsdk:"updated_at"`
-	TwilioAccountSid         types.String `tfsdk:"twilio_account_sid"`
-	TwilioAuthToken          types.String `tfsdk:"twilio_auth_token"`
-	Name                     types.String `tfsdk:"name"`
-	PhoneProvider            types.String `tfsdk:"phone_provider"`
-	FallbackType             types.String `tfsdk:"fallback_destination_type"`
-	FallbackE164CheckEnabled types.String `tfsdk:"fallback_destination_number_e164_check_enabled"`
-	FallbackNumber           types.String `tfsdk:"fallback_destination_number"`
-	FallbackExtension        types.String `tfsdk:"fallback_destination_extension"`
-	FallbackMessage          types.String `tfsdk:"fallback_destination_message"`
-	FallbackDescription      types.String `tfsdk:"fallback_destination_description"`
-	AssistantID              types.String `tfsdk:"assistant_id"`
+	ID                       types.String         `tfsdk:"id"`
+	OrgID                    types.String         `tfsdk:"org_id"`
+	Number                   types.String         `tfsdk:"number"`
+	CreatedAt                types.String         `tfsdk:"created_at"`
+	UpdatedAt                types.String         `tfsdk:"updated_at"`
+	TwilioAccountSid         types.String         `tfsdk:"twilio_account_sid"`
+	TwilioAuthToken          types.String         `tfsdk:"twilio_auth_token"`
+	Name                     types.String         `tfsdk:"name"`
+	PhoneProvider            types.String         `tfsdk:"phone_provider"`
+	FallbackType             types.String         `tfsdk:"fallback_destination_type"`
+	FallbackE164CheckEnabled types.String         `tfsdk:"fallback_destination_number_e164_check_enabled"`
+	FallbackNumber           types.String         `tfsdk:"fallback_destination_number"`
+	FallbackExtension        types.String         `tfsdk:"fallback_destination_extension"`
+	FallbackMessage          types.String         `tfsdk:"fallback_destination_message"`
+	FallbackDescription      types.String         `tfsdk:"fallback_destination_description"`
+	AssistantID              types.String         `tfsdk:"assistant_id"`
+	Server                   *ServerResourceModel `tfsdk:"server"`
 }
 
 func (r *VAPITwilioPhoneNumberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -124,6 +124,7 @@ func (r *VAPITwilioPhoneNumberResource) Schema(ctx context.Context, req resource
 				MarkdownDescription: "This is the assistant that will be used for incoming calls to this phone number.",
 				Optional:            true,
 			},
+			"server": serverAttribute("Webhook that receives events for calls to this number. Defaults to the assistant server."),
 		},
 	}
 }
@@ -166,26 +167,16 @@ func (r *VAPITwilioPhoneNumberResource) Create(ctx context.Context, req resource
 			Message:                data.FallbackMessage.ValueString(),
 			Description:            data.FallbackDescription.ValueString(),
 		},
+		Server: serverValue(buildServer(data.Server, types.StringNull(), types.StringNull()), false),
 	}
 
-	response, responseCode, err := r.client.ImportTwilioPhoneNumber(requestData)
+	twilioPhoneNumberResp, err := r.client.ImportTwilioPhoneNumber(ctx, requestData)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create phone number: %s", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to create phone number", err)
 		return
 	}
 
-	var twilioPhoneNumberResp vapi.TwilioPhoneNumber
-	if responseCode >= 200 && responseCode < 300 {
-		if err := json.Unmarshal(response, &twilioPhoneNumberResp); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unmarshal response: %s", err))
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	bindVAPIPhoneNumberResourceData(&data, &twilioPhoneNumberResp)
+	bindVAPIPhoneNumberResourceData(&data, twilioPhoneNumberResp)
 	tflog.Trace(ctx, "created a phone number resource")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -199,33 +190,21 @@ func (r *VAPITwilioPhoneNumberResource) Read(ctx context.Context, req resource.R
 	}
 
 	// Attempt to fetch the phone number details from the remote API
-	response, responseCode, err := r.client.GetPhoneNumber(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read phone number: %s", err))
-		return
-	}
+	phoneNumberResp, err := r.client.GetTwilioPhoneNumber(ctx, data.ID.ValueString())
 
 	// Check if the phone number was not found (404 or similar status code)
-	if responseCode == 404 {
+	if vapi.IsNotFound(err) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	// Handle successful responses (e.g., 200 OK)
-	var phoneNumberResp vapi.TwilioPhoneNumber
-	if responseCode >= 200 && responseCode < 300 {
-		// Parse the response
-		if err := json.Unmarshal(response, &phoneNumberResp); err != nil {
-			resp.Diagnostics.AddError("Parse Error", fmt.Sprintf("Unable to parse phone number response: %s", err))
-			return
-		}
-		// Bind the phone number response data to the resource model
-		bindVAPIPhoneNumberResourceData(&data, &phoneNumberResp)
-	} else {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
+	if err != nil {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to read phone number", err)
 		return
 	}
 
+	// Bind the phone number response data to the resource model
+	bindVAPIPhoneNumberResourceData(&data, phoneNumberResp)
+
 	// Update the state with the latest data
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -255,27 +234,17 @@ func (r *VAPITwilioPhoneNumberResource) Update(ctx context.Context, req resource
 			Message:                plan.FallbackMessage.ValueString(),
 			Description:            plan.FallbackDescription.ValueString(),
 		},
+		Server: serverValue(buildServer(plan.Server, types.StringNull(), types.StringNull()), state.Server != nil),
 	}
 
-	response, responseCode, err := r.client.UpdatePhoneNumber(state.ID.ValueString(), requestData)
+	phoneNumberResp, err := r.client.UpdateTwilioPhoneNumber(ctx, state.ID.ValueString(), requestData)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update phone number: %s", err))
-		return
-	}
-
-	if responseCode < 200 || responseCode >= 300 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse response [%d]: %s", responseCode, string(response)))
-		return
-	}
-
-	var phoneNumberResp vapi.TwilioPhoneNumber
-	if err := json.Unmarshal(response, &phoneNumberResp); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Update :: Unable to unmarshal response: %s", err))
+		addAPIError(ctx, &resp.Diagnostics, req.Plan.Schema, "Unable to update phone number", err)
 		return
 	}
 
 	data := plan
-	bindVAPIPhoneNumberResourceData(&data, &phoneNumberResp)
+	bindVAPIPhoneNumberResourceData(&data, phoneNumberResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -286,9 +255,9 @@ func (r *VAPITwilioPhoneNumberResource) Delete(ctx context.Context, req resource
 		return
 	}
 
-	_, _, err := r.client.DeletePhoneNumber(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete phone number: %s", err))
+	err := r.client.DeletePhoneNumber(ctx, data.ID.ValueString())
+	if err != nil && !vapi.IsNotFound(err) {
+		addAPIError(ctx, &resp.Diagnostics, req.State.Schema, "Unable to delete phone number", err)
 		return
 	}
 
@@ -306,6 +275,7 @@ func bindVAPIPhoneNumberResourceData(data *VAPITwilioPhoneNumberResourceModel, p
 	data.CreatedAt = types.StringValue(phoneNumberResp.CreatedAt)
 	data.UpdatedAt = types.StringValue(phoneNumberResp.UpdatedAt)
 	data.PhoneProvider = types.StringValue(phoneNumberResp.Provider)
+	data.Server = flattenServerBlock(phoneNumberResp.Server, data.Server)
 
 	if phoneNumberResp.Fallback != nil {
 		var numberE164CheckEnabled string
@@ -2,6 +2,8 @@ package vapi
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"mime"
 	"mime/multipart"
@@ -14,6 +16,8 @@ import (
 func TestUploadData(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	var snapshot multipartSnapshot
 	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
 		if req.Method != http.MethodPost || req.URL.Path != "/file" {
@@ -34,7 +38,7 @@ func TestUploadData(t *testing.T) {
 	}
 
 	body := []byte("content")
-	resp, status, err := client.UploadData("file", "example.txt", body)
+	resp, status, err := client.UploadData(ctx, "file", "example.txt", body)
 	if err != nil {
 		t.Fatalf("upload error: %v", err)
 	}
@@ -52,6 +56,8 @@ func TestUploadData(t *testing.T) {
 func TestSendRequestHandlesErrorStatus(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
 		return jsonResponse(http.StatusInternalServerError, "boom"), nil
 	})
@@ -62,7 +68,7 @@ func TestSendRequestHandlesErrorStatus(t *testing.T) {
 		HTTPClient: &http.Client{Transport: transport},
 	}
 
-	_, status, err := client.SendRequest(http.MethodGet, "resource", nil)
+	_, status, err := client.SendRequest(ctx, http.MethodGet, "resource", nil)
 	if err == nil {
 		t.Fatalf("expected error for non-2xx status")
 	}
@@ -71,9 +77,68 @@ func TestSendRequestHandlesErrorStatus(t *testing.T) {
 	}
 }
 
+func TestSendRequestSetsUserAgent(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.Header.Get("User-Agent"))
+		return jsonResponse(http.StatusOK, "{}"), nil
+	})
+
+	client := &APIClient{
+		BaseURL:    "https://api.example.com",
+		Token:      "token",
+		HTTPClient: &http.Client{Transport: transport},
+		UserAgent:  "terraform-provider-vapi/1.0.0 terraform/1.9.5",
+	}
+
+	if _, _, err := client.SendRequest(context.Background(), http.MethodGet, "resource", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := client.UploadData(context.Background(), "file", "example.txt", []byte("x")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ua := range got {
+		if ua != client.UserAgent {
+			t.Fatalf("unexpected User-Agent %q", ua)
+		}
+	}
+}
+
+func TestSendRequestHonoursContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	client := &APIClient{
+		BaseURL:    "https://api.example.com",
+		Token:      "token",
+		HTTPClient: &http.Client{Transport: transport},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := client.SendRequest(ctx, http.MethodGet, "resource", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	_, _, err = client.UploadData(ctx, "file", "example.txt", []byte("content"))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from upload, got %v", err)
+	}
+}
+
 func TestConvenienceEndpoints(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	qt := &queueTransport{t: t}
 
 	qt.enqueue("GET /file/file-1", http.StatusOK, `{"id":"file-1"}`)
@@ -88,6 +153,10 @@ func TestConvenienceEndpoints(t *testing.T) {
 	qt.enqueue("POST /tool", http.StatusOK, `{"id":"tool-2"}`)
 	qt.enqueue("PATCH /tool/tool", http.StatusOK, `{"id":"tool"}`)
 	qt.enqueue("DELETE /tool/tool", http.StatusOK, ``)
+	qt.enqueue("POST /tool", http.StatusCreated, `{"id":"tool-3","type":"endCall"}`)
+	qt.enqueue("GET /tool/tool", http.StatusOK, `{"id":"tool"}`)
+	qt.enqueue("PATCH /tool/tool", http.StatusOK, `{"id":"tool"}`)
+	qt.enqueue("DELETE /tool/tool", http.StatusOK, ``)
 	qt.enqueue("POST /assistant", http.StatusOK, `{"id":"assistant-1"}`)
 	qt.enqueue("PATCH /assistant/assistant", http.StatusOK, `{"id":"assistant"}`)
 	qt.enqueue("GET /assistant/assistant", http.StatusOK, `{"id":"assistant"}`)
@@ -104,72 +173,84 @@ func TestConvenienceEndpoints(t *testing.T) {
 		HTTPClient: &http.Client{Transport: qt},
 	}
 
-	if _, status, err := client.DeleteFile(""); err != nil || status != http.StatusNotFound {
-		t.Fatalf("expected 404 short circuit, got status %d err %v", status, err)
+	if err := client.DeleteFile(ctx, ""); !IsNotFound(err) {
+		t.Fatalf("expected not-found short circuit, got %v", err)
 	}
 
-	if _, status, err := client.GetFile("file-1"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("GetFile unexpected status %d err %v", status, err)
+	if file, err := client.GetFile(ctx, "file-1"); err != nil || file.ID != "file-1" {
+		t.Fatalf("GetFile unexpected result %#v err %v", file, err)
+	}
+	if err := client.DeleteFile(ctx, "file-1"); err != nil {
+		t.Fatalf("DeleteFile unexpected err %v", err)
+	}
+	if pn, err := client.ImportTwilioPhoneNumber(ctx, ImportTwilioRequest{}); err != nil || pn.ID != "pn-1" {
+		t.Fatalf("ImportTwilioPhoneNumber unexpected result %#v err %v", pn, err)
+	}
+	if err := client.DeletePhoneNumber(ctx, "pn"); err != nil {
+		t.Fatalf("DeletePhoneNumber unexpected err %v", err)
+	}
+	if pn, err := client.UpdateTwilioPhoneNumber(ctx, "pn-update", ImportTwilioRequest{}); err != nil || pn.ID != "pn-update" {
+		t.Fatalf("UpdateTwilioPhoneNumber unexpected result %#v err %v", pn, err)
 	}
-	if _, status, err := client.DeleteFile("file-1"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("DeleteFile unexpected status %d err %v", status, err)
+	if tool, err := client.CreateToolFunction(ctx, ToolFunctionRequest{}); err != nil || tool.ID != "tool-1" {
+		t.Fatalf("CreateToolFunction unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.ImportTwilioPhoneNumber(ImportTwilioRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("ImportTwilioPhoneNumber unexpected status %d err %v", status, err)
+	if tool, err := client.GetToolFunction(ctx, "tool"); err != nil || tool.ID != "tool" {
+		t.Fatalf("GetToolFunction unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.DeletePhoneNumber("pn"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("DeletePhoneNumber unexpected status %d err %v", status, err)
+	if tool, err := client.UpdateToolFunction(ctx, "tool", UpdateToolFunctionRequest{}); err != nil || tool.ID != "tool" {
+		t.Fatalf("UpdateToolFunction unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.UpdatePhoneNumber("pn-update", struct{}{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("UpdatePhoneNumber unexpected status %d err %v", status, err)
+	if err := client.DeleteToolFunction(ctx, "tool"); err != nil {
+		t.Fatalf("DeleteToolFunction unexpected err %v", err)
 	}
-	if _, status, err := client.CreateToolFunction(ToolFunctionRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("CreateToolFunction unexpected status %d err %v", status, err)
+	if tool, err := client.CreateToolQueryFunction(ctx, ToolQueryFunctionRequest{}); err != nil || tool.ID != "tool-2" {
+		t.Fatalf("CreateToolQueryFunction unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.GetToolFunction("tool"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("GetToolFunction unexpected status %d err %v", status, err)
+	if tool, err := client.UpdateToolQueryFunction(ctx, "tool", ToolQueryFunctionRequest{}); err != nil || tool.ID != "tool" {
+		t.Fatalf("UpdateToolQueryFunction unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.UpdateToolFunction("tool", ToolFunctionRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("UpdateToolFunction unexpected status %d err %v", status, err)
+	if err := client.DeleteToolQueryFunction(ctx, "tool"); err != nil {
+		t.Fatalf("DeleteToolQueryFunction unexpected err %v", err)
 	}
-	if _, status, err := client.DeleteToolFunction("tool"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("DeleteToolFunction unexpected status %d err %v", status, err)
+	if tool, err := client.CreateTool(ctx, ToolRequest{Type: "endCall"}); err != nil || tool.ID != "tool-3" || tool.Type != "endCall" {
+		t.Fatalf("CreateTool unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.CreateToolQueryFunction(ToolQueryFunctionRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("CreateToolQueryFunction unexpected status %d err %v", status, err)
+	if tool, err := client.GetTool(ctx, "tool"); err != nil || tool.ID != "tool" {
+		t.Fatalf("GetTool unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.UpdateToolQueryFunction("tool", ToolQueryFunctionRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("UpdateToolQueryFunction unexpected status %d err %v", status, err)
+	if tool, err := client.UpdateTool(ctx, "tool", ToolRequest{}); err != nil || tool.ID != "tool" {
+		t.Fatalf("UpdateTool unexpected result %#v err %v", tool, err)
 	}
-	if _, status, err := client.DeleteToolQueryFunction("tool"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("DeleteToolQueryFunction unexpected status %d err %v", status, err)
+	if err := client.DeleteTool(ctx, "tool"); err != nil {
+		t.Fatalf("DeleteTool unexpected err %v", err)
 	}
-	if _, status, err := client.CreateAssistant(CreateAssistantRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("CreateAssistant unexpected status %d err %v", status, err)
+	if assistant, err := client.CreateAssistant(ctx, CreateAssistantRequest{}); err != nil || assistant.ID != "assistant-1" {
+		t.Fatalf("CreateAssistant unexpected result %#v err %v", assistant, err)
 	}
-	if _, status, err := client.UpdateAssistant("assistant", CreateAssistantRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("UpdateAssistant unexpected status %d err %v", status, err)
+	if assistant, err := client.UpdateAssistant(ctx, "assistant", CreateAssistantRequest{}); err != nil || assistant.ID != "assistant" {
+		t.Fatalf("UpdateAssistant unexpected result %#v err %v", assistant, err)
 	}
-	if _, status, err := client.GetAssistant("assistant"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("GetAssistant unexpected status %d err %v", status, err)
+	if assistant, err := client.GetAssistant(ctx, "assistant"); err != nil || assistant.ID != "assistant" {
+		t.Fatalf("GetAssistant unexpected result %#v err %v", assistant, err)
 	}
-	if _, status, err := client.DeleteAssistant("assistant"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("DeleteAssistant unexpected status %d err %v", status, err)
+	if err := client.DeleteAssistant(ctx, "assistant"); err != nil {
+		t.Fatalf("DeleteAssistant unexpected err %v", err)
 	}
-	if _, status, err := client.CreateSIPTrunk(ImportSIPTrunkRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("CreateSIPTrunk unexpected status %d err %v", status, err)
+	if trunk, err := client.CreateSIPTrunk(ctx, ImportSIPTrunkRequest{}); err != nil || trunk.ID != "cred-1" {
+		t.Fatalf("CreateSIPTrunk unexpected result %#v err %v", trunk, err)
 	}
-	if _, status, err := client.UpdateSIPTrunk("trunk", ImportSIPTrunkRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("UpdateSIPTrunk unexpected status %d err %v", status, err)
+	if trunk, err := client.UpdateSIPTrunk(ctx, "trunk", ImportSIPTrunkRequest{}); err != nil || trunk.ID != "trunk" {
+		t.Fatalf("UpdateSIPTrunk unexpected result %#v err %v", trunk, err)
 	}
-	if _, status, err := client.GetSIPTrunk("trunk"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("GetSIPTrunk unexpected status %d err %v", status, err)
+	if trunk, err := client.GetSIPTrunk(ctx, "trunk"); err != nil || trunk.ID != "trunk" {
+		t.Fatalf("GetSIPTrunk unexpected result %#v err %v", trunk, err)
 	}
-	if _, status, err := client.DeleteSIPTrunk("trunk"); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("DeleteSIPTrunk unexpected status %d err %v", status, err)
+	if err := client.DeleteSIPTrunk(ctx, "trunk"); err != nil {
+		t.Fatalf("DeleteSIPTrunk unexpected err %v", err)
 	}
-	if _, status, err := client.ImportSIPTrunkPhoneNumber(ImportSIPTrunkPhoneNumberRequest{}); err != nil || status < 200 || status >= 300 {
-		t.Fatalf("ImportSIPTrunkPhoneNumber unexpected status %d err %v", status, err)
+	if pn, err := client.ImportSIPTrunkPhoneNumber(ctx, ImportSIPTrunkPhoneNumberRequest{}); err != nil || pn.ID != "pn-2" {
+		t.Fatalf("ImportSIPTrunkPhoneNumber unexpected result %#v err %v", pn, err)
 	}
 
 	qt.assertExhausted()
@@ -0,0 +1,108 @@
+package vapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Collection endpoints exposed by the Vapi API.
+const (
+	AssistantEndpoint   = "assistant"
+	CredentialEndpoint  = "credential"
+	FileEndpoint        = "file"
+	PhoneNumberEndpoint = "phone-number"
+	ToolEndpoint        = "tool"
+)
+
+// Create POSTs body to endpoint and decodes the created entity.
+func Create[T any](ctx context.Context, c *APIClient, endpoint string, body any) (*T, error) {
+	data, _, err := c.SendRequest(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return decode[T](endpoint, data)
+}
+
+// Get fetches the entity with the given ID from endpoint.
+func Get[T any](ctx context.Context, c *APIClient, endpoint, id string) (*T, error) {
+	if id == "" {
+		return nil, emptyIDError(http.MethodGet, endpoint)
+	}
+	data, _, err := c.SendRequest(ctx, http.MethodGet, endpoint+"/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[T](endpoint, data)
+}
+
+// Update PATCHes the entity with the given ID and decodes the result.
+func Update[T any](ctx context.Context, c *APIClient, endpoint, id string, body any) (*T, error) {
+	if id == "" {
+		return nil, emptyIDError(http.MethodPatch, endpoint)
+	}
+	data, _, err := c.SendRequest(ctx, http.MethodPatch, endpoint+"/"+url.PathEscape(id), body)
+	if err != nil {
+		return nil, err
+	}
+	return decode[T](endpoint, data)
+}
+
+// Delete removes the entity with the given ID. The response body is ignored.
+func Delete(ctx context.Context, c *APIClient, endpoint, id string) error {
+	if id == "" {
+		return emptyIDError(http.MethodDelete, endpoint)
+	}
+	_, _, err := c.SendRequest(ctx, http.MethodDelete, endpoint+"/"+url.PathEscape(id), nil)
+	return err
+}
+
+// List fetches a single page of entities from endpoint, passing query as URL
+// parameters.
+func List[T any](ctx context.Context, c *APIClient, endpoint string, query url.Values) ([]T, error) {
+	target := endpoint
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	data, _, err := c.SendRequest(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode %s list response: %w", endpoint, err)
+	}
+	return items, nil
+}
+
+func decode[T any](endpoint string, data []byte) (*T, error) {
+	out := new(T)
+	if len(data) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", endpoint, err)
+	}
+	return out, nil
+}
+
+// emptyIDError is returned instead of issuing a request for an entity without
+// an ID, so callers can treat it like any other missing entity.
+func emptyIDError(method, endpoint string) *APIError {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Method:     method,
+		Endpoint:   endpoint,
+		Messages:   []string{"ID cannot be empty"},
+	}
+}
+
+// VerifyCredentials issues the cheapest authenticated request available, a
+// single-item assistant list, so callers can fail fast on an invalid token.
+func (c *APIClient) VerifyCredentials(ctx context.Context) error {
+	_, err := List[json.RawMessage](ctx, c, AssistantEndpoint, url.Values{"limit": []string{"1"}})
+	return err
+}
@@ -0,0 +1,100 @@
+package vapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type widget struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGenericCRUD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Method+" "+r.URL.RequestURI())
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			if r.URL.Path == "/widget" {
+				_, _ = w.Write([]byte(`[{"id":"w-1","name":"one"},{"id":"w-2","name":"two"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"w-1","name":"one"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"w-1","name":"updated"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	created, err := Create[widget](ctx, client, "widget", map[string]string{"name": "one"})
+	if err != nil || created.ID != "w-1" {
+		t.Fatalf("Create returned %#v, %v", created, err)
+	}
+	got, err := Get[widget](ctx, client, "widget", "w-1")
+	if err != nil || got.Name != "one" {
+		t.Fatalf("Get returned %#v, %v", got, err)
+	}
+	updated, err := Update[widget](ctx, client, "widget", "w-1", map[string]string{"name": "updated"})
+	if err != nil || updated.Name != "updated" {
+		t.Fatalf("Update returned %#v, %v", updated, err)
+	}
+	items, err := List[widget](ctx, client, "widget", url.Values{"limit": []string{"2"}})
+	if err != nil || len(items) != 2 || items[1].ID != "w-2" {
+		t.Fatalf("List returned %#v, %v", items, err)
+	}
+	if err := Delete(ctx, client, "widget", "w-1"); err != nil {
+		t.Fatalf("Delete returned %v", err)
+	}
+
+	want := []string{
+		"POST /widget",
+		"GET /widget/w-1",
+		"PATCH /widget/w-1",
+		"GET /widget?limit=2",
+		"DELETE /widget/w-1",
+	}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected requests:\n got %v\nwant %v", seen, want)
+	}
+}
+
+func TestGenericCRUDEmptyIDAndDecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	if _, err := Get[widget](ctx, client, "widget", ""); !IsNotFound(err) {
+		t.Fatalf("expected not-found error for empty ID, got %v", err)
+	}
+	if _, err := Update[widget](ctx, client, "widget", "", nil); !IsNotFound(err) {
+		t.Fatalf("expected not-found error for empty ID, got %v", err)
+	}
+	if err := Delete(ctx, client, "widget", ""); !IsNotFound(err) {
+		t.Fatalf("expected not-found error for empty ID, got %v", err)
+	}
+
+	_, err := Get[widget](ctx, client, "widget", "w-1")
+	if err == nil || !strings.Contains(err.Error(), "failed to decode widget response") {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package vapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError describes a non-2xx response returned by the Vapi API.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	RequestID  string
+	// Reason is the short error name reported by the API, e.g. "Bad Request".
+	Reason string
+	// Messages holds the individual validation or error messages.
+	Messages []string
+	// Body is the raw response body, kept for debugging.
+	Body []byte
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s /%s returned %d", e.Method, e.Endpoint, e.StatusCode)
+	if e.Reason != "" {
+		fmt.Fprintf(&b, " %s", e.Reason)
+	} else if text := http.StatusText(e.StatusCode); text != "" {
+		fmt.Fprintf(&b, " %s", text)
+	}
+	if len(e.Messages) > 0 {
+		fmt.Fprintf(&b, ": %s", strings.Join(e.Messages, "; "))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// apiErrorBody mirrors the error payload returned by the API. The message
+// field is either a single string or a list of validation messages.
+type apiErrorBody struct {
+	Message json.RawMessage `json:"message"`
+	Error   string          `json:"error"`
+}
+
+func newAPIError(method, endpoint string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		RequestID:  requestID(resp.Header),
+		Body:       body,
+	}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		if text := strings.TrimSpace(string(body)); text != "" {
+			apiErr.Messages = []string{text}
+		}
+		return apiErr
+	}
+
+	apiErr.Reason = parsed.Error
+	var single string
+	var many []string
+	switch {
+	case json.Unmarshal(parsed.Message, &many) == nil:
+		apiErr.Messages = many
+	case json.Unmarshal(parsed.Message, &single) == nil && single != "":
+		apiErr.Messages = []string{single}
+	}
+	return apiErr
+}
+
+func requestID(header http.Header) string {
+	for _, key := range []string{"X-Request-Id", "Cf-Ray"} {
+		if value := header.Get(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError with status 409.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err is an APIError with status 429.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsUnauthorized reports whether the API rejected the credentials with
+// status 401 or 403.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+func hasStatus(err error, statuses ...int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, status := range statuses {
+		if apiErr.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package vapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendRequestReturnsAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":["voice.voiceId should not be empty","name must be a string"],"error":"Bad Request","statusCode":400}`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	_, status, err := client.SendRequest(context.Background(), http.MethodPost, "assistant", nil)
+	if status != http.StatusBadRequest {
+		t.Fatalf("unexpected status %d", status)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Method != http.MethodPost || apiErr.Endpoint != "assistant" || apiErr.RequestID != "req-123" || apiErr.Reason != "Bad Request" {
+		t.Fatalf("unexpected error fields: %#v", apiErr)
+	}
+	if len(apiErr.Messages) != 2 || apiErr.Messages[0] != "voice.voiceId should not be empty" {
+		t.Fatalf("unexpected messages: %#v", apiErr.Messages)
+	}
+
+	want := "POST /assistant returned 400 Bad Request: voice.voiceId should not be empty; name must be a string (request ID req-123)"
+	if apiErr.Error() != want {
+		t.Fatalf("unexpected error string:\n got %s\nwant %s", apiErr.Error(), want)
+	}
+}
+
+func TestNewAPIErrorMessageShapes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		body     string
+		messages []string
+		reason   string
+	}{
+		{name: "string message", body: `{"message":"Not Found","error":"Not Found"}`, messages: []string{"Not Found"}, reason: "Not Found"},
+		{name: "message list", body: `{"message":["a","b"]}`, messages: []string{"a", "b"}},
+		{name: "plain text", body: "upstream timeout", messages: []string{"upstream timeout"}},
+		{name: "empty body", body: "", messages: nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+			apiErr := newAPIError(http.MethodGet, "assistant/a", resp, []byte(tc.body))
+			if fmt.Sprint(apiErr.Messages) != fmt.Sprint(tc.messages) {
+				t.Fatalf("unexpected messages %#v", apiErr.Messages)
+			}
+			if apiErr.Reason != tc.reason {
+				t.Fatalf("unexpected reason %q", apiErr.Reason)
+			}
+		})
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	t.Parallel()
+
+	wrap := func(status int) error {
+		return fmt.Errorf("wrapped: %w", &APIError{StatusCode: status})
+	}
+
+	if !IsNotFound(wrap(http.StatusNotFound)) || IsNotFound(wrap(http.StatusBadRequest)) {
+		t.Fatalf("IsNotFound mismatch")
+	}
+	if !IsConflict(wrap(http.StatusConflict)) {
+		t.Fatalf("IsConflict mismatch")
+	}
+	if !IsRateLimited(wrap(http.StatusTooManyRequests)) {
+		t.Fatalf("IsRateLimited mismatch")
+	}
+	if !IsUnauthorized(wrap(http.StatusUnauthorized)) || !IsUnauthorized(wrap(http.StatusForbidden)) {
+		t.Fatalf("IsUnauthorized mismatch")
+	}
+	if IsNotFound(errors.New("plain")) || IsNotFound(nil) {
+		t.Fatalf("non-API errors must not match")
+	}
+}
@@ -0,0 +1,199 @@
+package vapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem used for HTTP request logging. Its
+// level can be set independently with TF_LOG_PROVIDER_VAPI_HTTP.
+const LogSubsystem = "vapi_http"
+
+const (
+	redacted        = "***"
+	maxLoggedBody   = 8 << 10
+	truncatedMarker = "...(truncated)"
+)
+
+// sensitiveBodyKeys lists JSON keys whose values are never logged.
+var sensitiveBodyKeys = map[string]bool{
+	"apikey":          true,
+	"authpassword":    true,
+	"password":        true,
+	"secret":          true,
+	"serverurlsecret": true,
+	"token":           true,
+	"twilioauthtoken": true,
+}
+
+// headerBodyKeys lists JSON keys that hold a map of header names to values,
+// such as server headers and SIP headers. The names are logged, the values
+// never are, since they often carry credentials.
+var headerBodyKeys = map[string]bool{
+	"headers":    true,
+	"sipheaders": true,
+}
+
+// sensitiveHeaderFragments marks headers whose values are never logged. The
+// "auth" fragment also covers Authorization and custom names such as X-Auth
+// or X-Api-Auth.
+var sensitiveHeaderFragments = []string{"auth", "cookie", "key", "password", "secret", "token"}
+
+// loggingTransport logs every request and response through the tflog
+// LogSubsystem with credentials and file contents redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), LogSubsystem)
+
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    req.URL.String(),
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending HTTP request", mergeFields(fields, map[string]interface{}{
+		"http_request_body": requestBodyForLog(req),
+	}))
+	tflog.SubsystemTrace(ctx, LogSubsystem, "HTTP request headers", mergeFields(fields, map[string]interface{}{
+		"http_request_headers": headersForLog(req.Header),
+	}))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "HTTP request failed", mergeFields(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+		return resp, err
+	}
+
+	fields["http_status_code"] = resp.StatusCode
+	if id := requestID(resp.Header); id != "" {
+		fields["http_request_id"] = id
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Received HTTP response", mergeFields(fields, map[string]interface{}{
+			"error": readErr.Error(),
+		}))
+		return resp, readErr
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received HTTP response", fields)
+	tflog.SubsystemTrace(ctx, LogSubsystem, "HTTP response body", mergeFields(fields, map[string]interface{}{
+		"http_response_body": bodyForLog(resp.Header.Get("Content-Type"), body),
+	}))
+	return resp, nil
+}
+
+func mergeFields(base, extra map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+func requestBodyForLog(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+
+	body, err := io.ReadAll(rc)
+	if err != nil {
+		return ""
+	}
+	return bodyForLog(req.Header.Get("Content-Type"), body)
+}
+
+// bodyForLog renders a body for logging. JSON payloads have sensitive keys
+// masked, multipart payloads (file uploads) are summarised and everything is
+// truncated to a bounded size.
+func bodyForLog(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return fmt.Sprintf("[%s body omitted: %d bytes]", mediaType, len(body))
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if masked, err := json.Marshal(redactJSON(decoded)); err == nil {
+			body = masked
+		}
+	} else if mediaType != "" && !strings.HasPrefix(mediaType, "text/") && mediaType != "application/json" {
+		return fmt.Sprintf("[%s body omitted: %d bytes]", mediaType, len(body))
+	}
+
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + truncatedMarker
+	}
+	return string(body)
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if sensitiveBodyKeys[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			if headers, ok := inner.(map[string]interface{}); ok && headerBodyKeys[strings.ToLower(key)] {
+				for name := range headers {
+					headers[name] = redacted
+				}
+				continue
+			}
+			v[key] = redactJSON(inner)
+		}
+		return v
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = redactJSON(inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func headersForLog(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for name, values := range header {
+		lower := strings.ToLower(name)
+		value := strings.Join(values, ", ")
+		for _, fragment := range sensitiveHeaderFragments {
+			if strings.Contains(lower, fragment) {
+				value = redacted
+				break
+			}
+		}
+		out[name] = value
+	}
+	return out
+}
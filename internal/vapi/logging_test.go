@@ -0,0 +1,117 @@
+package vapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransportRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"pn-1","twilioAuthToken":"response-token","server":{"url":"https://hook","secret":"response-secret","headers":{"Authorization":"Bearer response-header"}}}`))
+	}))
+	defer server.Close()
+
+	httpClient, err := NewHTTPClient(TransportOptions{Headers: map[string]string{
+		"X-Api-Key":  "header-key",
+		"X-Auth":     "header-auth",
+		"X-Api-Auth": "header-api-auth",
+		"X-Team":     "voice",
+	}})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	client := &APIClient{BaseURL: server.URL, Token: "bearer-token", HTTPClient: httpClient}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err = client.ImportTwilioPhoneNumber(ctx, ImportTwilioRequest{
+		Provider:        "twilio",
+		Number:          "+15550100",
+		TwilioAuthToken: "request-token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _, err = client.SendRequest(ctx, http.MethodPost, "credential", map[string]interface{}{
+		"outboundAuthenticationPlan": map[string]string{"authUsername": "user", "authPassword": "request-password"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := client.UploadData(ctx, "file", "notes.txt", []byte("file-contents")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"bearer-token", "request-token", "response-token", "response-secret", "request-password", "header-key", "header-auth", "header-api-auth", "response-header", "file-contents"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("log output leaked %q", secret)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decode logs: %v", err)
+	}
+
+	var sawResponse, sawMultipart bool
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+LogSubsystem {
+			continue
+		}
+		if entry["@message"] == "Received HTTP response" && entry["http_method"] == http.MethodPost {
+			sawResponse = entry["http_request_id"] == "req-42" && entry["http_status_code"] == float64(http.StatusCreated) && entry["http_duration_ms"] != nil
+		}
+		if body, ok := entry["http_request_body"].(string); ok && strings.HasPrefix(body, "[multipart/form-data body omitted") {
+			sawMultipart = true
+		}
+	}
+	if !sawResponse {
+		t.Errorf("expected response entry with status, latency and request ID:\n%s", logs)
+	}
+	if !strings.Contains(logs, `"X-Team":"voice"`) {
+		t.Errorf("expected non-sensitive header values to be logged:\n%s", logs)
+	}
+	if !sawMultipart {
+		t.Errorf("expected multipart body to be summarised:\n%s", logs)
+	}
+}
+
+func TestBodyForLog(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{name: "empty", contentType: "application/json", body: "", want: ""},
+		{name: "nested secret", contentType: "application/json", body: `{"server":{"secret":"s"},"list":[{"authPassword":"p"}]}`, want: `{"list":[{"authPassword":"***"}],"server":{"secret":"***"}}`},
+		{name: "server headers", contentType: "application/json", body: `{"server":{"url":"https://hook","headers":{"Authorization":"Bearer abc","X-Team":"support"}}}`, want: `{"server":{"headers":{"Authorization":"***","X-Team":"***"},"url":"https://hook"}}`},
+		{name: "sip headers", contentType: "application/json", body: `{"destinations":[{"type":"sip","sipUri":"sip:a@example.com","sipHeaders":{"X-Auth-Token":"t","X-Team":"billing"}}]}`, want: `{"destinations":[{"sipHeaders":{"X-Auth-Token":"***","X-Team":"***"},"sipUri":"sip:a@example.com","type":"sip"}]}`},
+		{name: "plain text", contentType: "text/plain", body: "gateway timeout", want: "gateway timeout"},
+		{name: "binary", contentType: "application/octet-stream", body: "\x00\x01", want: "[application/octet-stream body omitted: 2 bytes]"},
+		{name: "truncated", contentType: "text/plain", body: strings.Repeat("a", maxLoggedBody+1), want: strings.Repeat("a", maxLoggedBody) + truncatedMarker},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := bodyForLog(tc.contentType, []byte(tc.body)); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
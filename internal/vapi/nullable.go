@@ -0,0 +1,69 @@
+package vapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a request field with three states: omitted, an explicit JSON
+// null, or a value. Declare it with the omitzero option so that unset fields
+// are left out of the payload:
+//
+//	HipaaEnabled Nullable[bool] `json:"hipaaEnabled,omitzero"`
+//
+// An explicit null asks the API to reset the field to its default, which is
+// how attributes removed from configuration are cleared on update.
+type Nullable[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// NewNullable returns a Nullable holding v. Zero values such as false, 0 and
+// "" are sent as-is.
+func NewNullable[T any](v T) Nullable[T] {
+	return Nullable[T]{value: v, set: true}
+}
+
+// ExplicitNull returns a Nullable that is sent as JSON null.
+func ExplicitNull[T any]() Nullable[T] {
+	return Nullable[T]{set: true, null: true}
+}
+
+// IsZero reports whether the field is unset. It is used by omitzero.
+func (n Nullable[T]) IsZero() bool {
+	return !n.set
+}
+
+// IsNull reports whether the field is an explicit null.
+func (n Nullable[T]) IsNull() bool {
+	return n.set && n.null
+}
+
+// Get returns the value and whether one is present.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.value, n.set && !n.null
+}
+
+// MarshalJSON encodes the value, or null for an explicit null.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.set || n.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON decodes null as an explicit null and anything else as a
+// value.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = ExplicitNull[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = NewNullable(v)
+	return nil
+}
@@ -0,0 +1,64 @@
+package vapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullableMarshal(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Enabled Nullable[bool]   `json:"enabled,omitzero"`
+		Count   Nullable[int64]  `json:"count,omitzero"`
+		Name    Nullable[string] `json:"name,omitzero"`
+	}
+
+	tests := []struct {
+		name string
+		in   payload
+		want string
+	}{
+		{"unset", payload{}, `{}`},
+		{"zero values", payload{NewNullable(false), NewNullable(int64(0)), NewNullable("")}, `{"enabled":false,"count":0,"name":""}`},
+		{"explicit null", payload{ExplicitNull[bool](), ExplicitNull[int64](), ExplicitNull[string]()}, `{"enabled":null,"count":null,"name":null}`},
+		{"values", payload{NewNullable(true), NewNullable(int64(3)), NewNullable("x")}, `{"enabled":true,"count":3,"name":"x"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNullableUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var out struct {
+		Null  Nullable[bool] `json:"null"`
+		False Nullable[bool] `json:"false"`
+		Unset Nullable[bool] `json:"unset"`
+	}
+	if err := json.Unmarshal([]byte(`{"null":null,"false":false}`), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if !out.Null.IsNull() {
+		t.Fatalf("expected explicit null, got %#v", out.Null)
+	}
+	if v, ok := out.False.Get(); !ok || v {
+		t.Fatalf("expected false value, got %#v", out.False)
+	}
+	if !out.Unset.IsZero() {
+		t.Fatalf("expected unset field, got %#v", out.Unset)
+	}
+}
@@ -0,0 +1,136 @@
+package vapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultPageSize is the page size requested when ListOptions.Limit is zero.
+const DefaultPageSize = 100
+
+// ListOptions narrows list requests. Zero values are omitted.
+type ListOptions struct {
+	// Limit is the number of entities requested per page.
+	Limit int
+	// CreatedAtGt and CreatedAtLt restrict results to entities created
+	// strictly after or before the given instants.
+	CreatedAtGt time.Time
+	CreatedAtLt time.Time
+}
+
+func (o ListOptions) query() (url.Values, int) {
+	limit := o.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if !o.CreatedAtGt.IsZero() {
+		query.Set("createdAtGt", o.CreatedAtGt.UTC().Format(time.RFC3339Nano))
+	}
+	if !o.CreatedAtLt.IsZero() {
+		query.Set("createdAtLt", o.CreatedAtLt.UTC().Format(time.RFC3339Nano))
+	}
+	return query, limit
+}
+
+// Paginate iterates over every entity at endpoint. Vapi returns entities
+// newest first, so each following page is requested with createdAtLe set to
+// the createdAt of the last entity received, and the entities already yielded
+// at that instant are skipped. Entities that share a timestamp across a page
+// boundary are therefore not lost. When a whole page holds only entities
+// already yielded, the next page moves past the instant with createdAtLt.
+// Pages are fetched lazily; the first error is yielded once and ends the
+// iteration.
+func Paginate[T any](ctx context.Context, c *APIClient, endpoint string, opts ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		query, limit := opts.query()
+
+		// cursor is the createdAt of the last entity yielded and seen holds
+		// the IDs yielded at that instant.
+		var cursor string
+		seen := map[string]bool{}
+		for {
+			page, err := List[json.RawMessage](ctx, c, endpoint, query)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			fresh := 0
+			for _, raw := range page {
+				var item T
+				if err := json.Unmarshal(raw, &item); err != nil {
+					yield(zero, fmt.Errorf("failed to decode %s list item: %w", endpoint, err))
+					return
+				}
+
+				var meta struct {
+					ID        string `json:"id"`
+					CreatedAt string `json:"createdAt"`
+				}
+				if err := json.Unmarshal(raw, &meta); err == nil && meta.CreatedAt != "" {
+					if meta.CreatedAt == cursor && seen[meta.ID] {
+						continue
+					}
+					if meta.CreatedAt != cursor {
+						cursor = meta.CreatedAt
+						clear(seen)
+					}
+					seen[meta.ID] = true
+				}
+				fresh++
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(page) < limit || cursor == "" {
+				return
+			}
+			if fresh > 0 {
+				query.Del("createdAtLt")
+				query.Set("createdAtLe", cursor)
+				continue
+			}
+			if query.Get("createdAtLt") == cursor {
+				return
+			}
+			query.Del("createdAtLe")
+			query.Set("createdAtLt", cursor)
+		}
+	}
+}
+
+// ListAssistants iterates over all assistants in the organization.
+func (c *APIClient) ListAssistants(ctx context.Context, opts ListOptions) iter.Seq2[Assistant, error] {
+	return Paginate[Assistant](ctx, c, AssistantEndpoint, opts)
+}
+
+// ListFiles iterates over all uploaded files.
+func (c *APIClient) ListFiles(ctx context.Context, opts ListOptions) iter.Seq2[FileResponse, error] {
+	return Paginate[FileResponse](ctx, c, FileEndpoint, opts)
+}
+
+// ListTools iterates over all tools regardless of their type.
+func (c *APIClient) ListTools(ctx context.Context, opts ListOptions) iter.Seq2[Tool, error] {
+	return Paginate[Tool](ctx, c, ToolEndpoint, opts)
+}
+
+// ListPhoneNumbers iterates over all phone numbers regardless of provider.
+func (c *APIClient) ListPhoneNumbers(ctx context.Context, opts ListOptions) iter.Seq2[PhoneNumber, error] {
+	return Paginate[PhoneNumber](ctx, c, PhoneNumberEndpoint, opts)
+}
+
+// ListCredentials iterates over all provider credentials.
+func (c *APIClient) ListCredentials(ctx context.Context, opts ListOptions) iter.Seq2[Credential, error] {
+	return Paginate[Credential](ctx, c, CredentialEndpoint, opts)
+}
@@ -0,0 +1,259 @@
+package vapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type paginatedItem struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// paginatedServer serves n assistants newest first, one minute apart.
+func paginatedServer(t *testing.T, n int) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]paginatedItem, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		items = append(items, paginatedItem{
+			ID:        fmt.Sprintf("a-%d", i),
+			CreatedAt: base.Add(time.Duration(i) * time.Minute).Format("2006-01-02T15:04:05.000Z"),
+		})
+	}
+	return paginatedItemsServer(t, items)
+}
+
+// paginatedItemsServer serves items, which are newest first, and honours the
+// limit and createdAtLt/createdAtLe/createdAtGt parameters the way the Vapi
+// API does.
+func paginatedItemsServer(t *testing.T, items []paginatedItem) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+
+		q := r.URL.Query()
+		limit, err := strconv.Atoi(q.Get("limit"))
+		if err != nil {
+			t.Errorf("missing limit: %q", r.URL.RawQuery)
+		}
+
+		page := []paginatedItem{}
+		for _, it := range items {
+			created, _ := time.Parse(time.RFC3339Nano, it.CreatedAt)
+			if lt := q.Get("createdAtLt"); lt != "" {
+				bound, _ := time.Parse(time.RFC3339Nano, lt)
+				if !created.Before(bound) {
+					continue
+				}
+			}
+			if le := q.Get("createdAtLe"); le != "" {
+				bound, _ := time.Parse(time.RFC3339Nano, le)
+				if created.After(bound) {
+					continue
+				}
+			}
+			if gt := q.Get("createdAtGt"); gt != "" {
+				bound, _ := time.Parse(time.RFC3339Nano, gt)
+				if !created.After(bound) {
+					continue
+				}
+			}
+			if len(page) == limit {
+				break
+			}
+			page = append(page, it)
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+func TestListAssistantsPaginates(t *testing.T) {
+	t.Parallel()
+
+	server, queries := paginatedServer(t, 5)
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	var ids []string
+	for assistant, err := range client.ListAssistants(context.Background(), ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, assistant.ID)
+	}
+
+	if fmt.Sprint(ids) != "[a-4 a-3 a-2 a-1 a-0]" {
+		t.Fatalf("unexpected ids %v", ids)
+	}
+
+	want := []string{
+		"limit=2",
+		"createdAtLe=2024-01-01T00%3A03%3A00.000Z&limit=2",
+		"createdAtLe=2024-01-01T00%3A02%3A00.000Z&limit=2",
+		"createdAtLe=2024-01-01T00%3A01%3A00.000Z&limit=2",
+		"createdAtLe=2024-01-01T00%3A00%3A00.000Z&limit=2",
+	}
+	if fmt.Sprint(queries()) != fmt.Sprint(want) {
+		t.Fatalf("unexpected queries:\n got %v\nwant %v", queries(), want)
+	}
+}
+
+func TestListKeepsEntitiesSharingATimestampAcrossPages(t *testing.T) {
+	t.Parallel()
+
+	server, queries := paginatedItemsServer(t, []paginatedItem{
+		{ID: "a-3", CreatedAt: "2024-01-01T00:03:00.000Z"},
+		{ID: "a-2b", CreatedAt: "2024-01-01T00:02:00.000Z"},
+		{ID: "a-2a", CreatedAt: "2024-01-01T00:02:00.000Z"},
+		{ID: "a-1", CreatedAt: "2024-01-01T00:01:00.000Z"},
+	})
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	var ids []string
+	for assistant, err := range client.ListAssistants(context.Background(), ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, assistant.ID)
+	}
+
+	if fmt.Sprint(ids) != "[a-3 a-2b a-2a a-1]" {
+		t.Fatalf("unexpected ids %v, queries %v", ids, queries())
+	}
+}
+
+func TestListMovesPastAFullPageOfOneTimestamp(t *testing.T) {
+	t.Parallel()
+
+	server, queries := paginatedItemsServer(t, []paginatedItem{
+		{ID: "a-2b", CreatedAt: "2024-01-01T00:02:00.000Z"},
+		{ID: "a-2a", CreatedAt: "2024-01-01T00:02:00.000Z"},
+		{ID: "a-1", CreatedAt: "2024-01-01T00:01:00.000Z"},
+	})
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	var ids []string
+	for assistant, err := range client.ListAssistants(context.Background(), ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, assistant.ID)
+	}
+
+	if fmt.Sprint(ids) != "[a-2b a-2a a-1]" {
+		t.Fatalf("unexpected ids %v, queries %v", ids, queries())
+	}
+	want := []string{
+		"limit=2",
+		"createdAtLe=2024-01-01T00%3A02%3A00.000Z&limit=2",
+		"createdAtLt=2024-01-01T00%3A02%3A00.000Z&limit=2",
+	}
+	if fmt.Sprint(queries()) != fmt.Sprint(want) {
+		t.Fatalf("unexpected queries:\n got %v\nwant %v", queries(), want)
+	}
+}
+
+func TestListStopsWhenConsumerBreaks(t *testing.T) {
+	t.Parallel()
+
+	server, queries := paginatedServer(t, 10)
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	count := 0
+	for _, err := range client.ListFiles(context.Background(), ListOptions{Limit: 3}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if count == 4 {
+			break
+		}
+	}
+
+	if got := len(queries()); got != 2 {
+		t.Fatalf("expected 2 page requests, got %d", got)
+	}
+}
+
+func TestListAppliesCreatedAtFilters(t *testing.T) {
+	t.Parallel()
+
+	server, queries := paginatedServer(t, 6)
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	opts := ListOptions{
+		CreatedAtGt: time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+		CreatedAtLt: time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC),
+	}
+
+	var ids []string
+	for tool, err := range client.ListTools(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, tool.ID)
+	}
+
+	if fmt.Sprint(ids) != "[a-4 a-3 a-2]" {
+		t.Fatalf("unexpected ids %v", ids)
+	}
+	want := "createdAtGt=2024-01-01T00%3A01%3A00Z&createdAtLt=2024-01-01T00%3A05%3A00Z&limit=100"
+	if q := queries(); len(q) != 1 || q[0] != want {
+		t.Fatalf("unexpected queries %v", q)
+	}
+}
+
+func TestListYieldsAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Invalid Key","error":"Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	var errs []error
+	for _, err := range client.ListCredentials(context.Background(), ListOptions{}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !IsUnauthorized(errs[0]) {
+		t.Fatalf("expected a single unauthorized error, got %v", errs)
+	}
+
+	for _, err := range client.ListPhoneNumbers(context.Background(), ListOptions{}) {
+		if !IsUnauthorized(err) {
+			t.Fatalf("expected unauthorized error, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package vapi
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry settings used by the provider when none are configured.
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryMinWait = 1 * time.Second
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// isIdempotent reports whether a request with the given method can be safely
+// repeated after an ambiguous failure.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a response status warrants another attempt.
+// Rate-limited requests were never processed, so they are retried for every
+// method; gateway errors are only retried for idempotent methods.
+func shouldRetry(method string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header takes precedence over the jittered exponential delay; both are capped
+// at the maximum wait.
+func (c *APIClient) backoff(attempt int, header http.Header) time.Duration {
+	minWait := c.RetryMinWait
+	if minWait <= 0 {
+		minWait = DefaultRetryMinWait
+	}
+	maxWait := c.RetryMaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+
+	if wait, ok := parseRetryAfter(header); ok {
+		return min(wait, maxWait)
+	}
+
+	wait := minWait
+	for i := 0; i < attempt && wait < maxWait; i++ {
+		wait *= 2
+	}
+	wait = min(wait, maxWait)
+
+	// Full jitter over the upper half of the window keeps concurrent
+	// retries from stampeding while still growing the delay.
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header expressed either in seconds or as
+// an HTTP date.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,167 @@
+package vapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendRequestRetriesTransientStatuses(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "{\"name\":\"a\"}\n" {
+			t.Errorf("request body not replayed, got %q", body)
+		}
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"id":"ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := retryTestClient(server, 3)
+
+	resp, status, err := client.SendRequest(context.Background(), http.MethodPut, "assistant/a", map[string]string{"name": "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusOK || string(resp) != `{"id":"ok"}` {
+		t.Fatalf("unexpected response %d %s", status, resp)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestSendRequestRetryLimits(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name          string
+		method        string
+		status        int
+		expectedCalls int32
+	}{
+		{name: "gives up after max retries", method: http.MethodGet, status: http.StatusServiceUnavailable, expectedCalls: 3},
+		{name: "rate limit retried for POST", method: http.MethodPost, status: http.StatusTooManyRequests, expectedCalls: 3},
+		{name: "gateway error not retried for POST", method: http.MethodPost, status: http.StatusBadGateway, expectedCalls: 1},
+		{name: "gateway error not retried for PATCH", method: http.MethodPatch, status: http.StatusServiceUnavailable, expectedCalls: 1},
+		{name: "client error not retried", method: http.MethodGet, status: http.StatusBadRequest, expectedCalls: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			client := retryTestClient(server, 2)
+
+			_, status, err := client.SendRequest(context.Background(), tc.method, "resource", nil)
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if status != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, status)
+			}
+			if calls.Load() != tc.expectedCalls {
+				t.Fatalf("expected %d attempts, got %d", tc.expectedCalls, calls.Load())
+			}
+		})
+	}
+}
+
+func TestSendRequestHonoursRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := retryTestClient(server, 1)
+	client.RetryMaxWait = 5 * time.Second
+
+	if _, _, err := client.SendRequest(context.Background(), http.MethodPost, "resource", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waited := second.Sub(first); waited < time.Second {
+		t.Fatalf("expected to wait at least 1s for Retry-After, waited %s", waited)
+	}
+}
+
+func TestSendRequestRetryStopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := retryTestClient(server, 5)
+	client.RetryMaxWait = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.SendRequest(ctx, http.MethodGet, "resource", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	t.Parallel()
+
+	client := &APIClient{RetryMinWait: 100 * time.Millisecond, RetryMaxWait: time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := client.backoff(attempt, nil)
+		if wait < 50*time.Millisecond || wait > time.Second {
+			t.Fatalf("attempt %d: backoff %s out of bounds", attempt, wait)
+		}
+	}
+
+	header := http.Header{"Retry-After": []string{"120"}}
+	if wait := client.backoff(0, header); wait != time.Second {
+		t.Fatalf("expected Retry-After to be capped at max wait, got %s", wait)
+	}
+}
+
+func retryTestClient(server *httptest.Server, maxRetries int) *APIClient {
+	return &APIClient{
+		BaseURL:      server.URL,
+		Token:        "token",
+		HTTPClient:   server.Client(),
+		MaxRetries:   maxRetries,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: 5 * time.Millisecond,
+	}
+}
@@ -0,0 +1,44 @@
+package vapi
+
+import (
+	"context"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// acquire blocks until the client's rate limiter and concurrency cap allow
+// another request. The returned function releases the concurrency slot and
+// must be called once the response has been consumed.
+func (c *APIClient) acquire(ctx context.Context) (func(), error) {
+	c.throttleOnce.Do(c.initThrottle)
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// initThrottle builds the shared token bucket and semaphore from the client
+// settings. Zero values leave the corresponding limit disabled.
+func (c *APIClient) initThrottle() {
+	if c.RequestsPerSecond > 0 {
+		burst := max(1, int(math.Ceil(c.RequestsPerSecond)))
+		c.limiter = rate.NewLimiter(rate.Limit(c.RequestsPerSecond), burst)
+	}
+	if c.MaxConcurrentRequests > 0 {
+		c.slots = make(chan struct{}, c.MaxConcurrentRequests)
+	}
+}
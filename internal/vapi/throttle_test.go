@@ -0,0 +1,118 @@
+package vapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequestsCapsInFlight(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := peak.Load()
+			if current <= prev || peak.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{
+		BaseURL:               server.URL,
+		Token:                 "token",
+		HTTPClient:            server.Client(),
+		MaxConcurrentRequests: 2,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.SendRequest(context.Background(), http.MethodGet, "assistant/a", nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, saw %d", peak.Load())
+	}
+}
+
+func TestRequestsPerSecondThrottlesBursts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &APIClient{
+		BaseURL:           server.URL,
+		Token:             "token",
+		HTTPClient:        server.Client(),
+		RequestsPerSecond: 5,
+	}
+
+	// The bucket starts with a burst of 5 tokens; the remaining 3 requests
+	// each wait 200ms for a refill.
+	start := time.Now()
+	for i := 0; i < 8; i++ {
+		if _, _, err := client.SendRequest(context.Background(), http.MethodGet, "assistant/a", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestThrottleRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &APIClient{
+		BaseURL:               server.URL,
+		Token:                 "token",
+		HTTPClient:            server.Client(),
+		MaxConcurrentRequests: 1,
+	}
+
+	go func() {
+		_, _, _ = client.SendRequest(context.Background(), http.MethodGet, "assistant/blocking", nil)
+	}()
+
+	// Wait for the first request to occupy the only slot.
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.SendRequest(ctx, http.MethodGet, "assistant/queued", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded while waiting for a slot, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package vapi
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serverCertPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestNewHTTPClientTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"a"}`))
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name    string
+		opts    TransportOptions
+		wantErr bool
+	}{
+		{name: "untrusted certificate", opts: TransportOptions{}, wantErr: true},
+		{name: "custom CA", opts: TransportOptions{CACertPEM: serverCertPEM(server)}},
+		{name: "skip verification", opts: TransportOptions{InsecureSkipVerify: true}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, err := NewHTTPClient(tc.opts)
+			if err != nil {
+				t.Fatalf("NewHTTPClient: %v", err)
+			}
+			client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: httpClient}
+
+			_, err = client.GetAssistant(context.Background(), "a")
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("wantErr=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientExtraHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Team"); got != "voice" {
+			t.Errorf("expected X-Team header, got %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("extra headers must not override Authorization, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"id":"a"}`))
+	}))
+	defer server.Close()
+
+	httpClient, err := NewHTTPClient(TransportOptions{
+		CACertPEM: serverCertPEM(server),
+		Headers:   map[string]string{"x-team": "voice", "Authorization": "Bearer other"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: httpClient}
+
+	if _, err := client.GetAssistant(context.Background(), "a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewHTTPClientTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient, err := NewHTTPClient(TransportOptions{Timeout: 50 * time.Millisecond, InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	client := &APIClient{BaseURL: server.URL, Token: "token", HTTPClient: httpClient}
+
+	start := time.Now()
+	if _, err := client.GetAssistant(context.Background(), "a"); err == nil {
+		t.Fatalf("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("timeout not applied, request took %s", elapsed)
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "vapi.invalid" {
+			t.Errorf("expected proxied request for vapi.invalid, got %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"id":"proxied"}`))
+	}))
+	defer proxy.Close()
+
+	httpClient, err := NewHTTPClient(TransportOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewHTTPClient: %v", err)
+	}
+	client := &APIClient{BaseURL: "http://vapi.invalid", Token: "token", HTTPClient: httpClient}
+
+	assistant, err := client.GetAssistant(context.Background(), "a")
+	if err != nil || assistant.ID != "proxied" {
+		t.Fatalf("unexpected result %#v, %v", assistant, err)
+	}
+}
+
+func TestNewHTTPClientRejectsInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewHTTPClient(TransportOptions{ProxyURL: "not a url"}); !errors.Is(err, ErrInvalidProxyURL) {
+		t.Fatalf("expected ErrInvalidProxyURL, got %v", err)
+	}
+	if _, err := NewHTTPClient(TransportOptions{CACertPEM: "garbage"}); !errors.Is(err, ErrInvalidCACert) {
+		t.Fatalf("expected ErrInvalidCACert, got %v", err)
+	}
+}
@@ -1,134 +1,265 @@
 package vapi
 
+import "encoding/json"
+
 // CreateAssistantRequest represents the request body for creating an assistant.
+// Optional scalars are Nullable so that false, 0 and "" are sent and fields
+// removed from configuration can be reset with an explicit null. Lists and
+// nested objects are Nullable too so that a block removed from configuration
+// can be cleared. Most nested objects are replaced as a whole by the API, so
+// their optional scalars are plain pointers; AnalysisPlan and ArtifactPlan are
+// merged instead and have request types of their own.
 type CreateAssistantRequest struct {
-	Name                         string             `json:"name"`
-	FirstMessageMode             string             `json:"firstMessageMode,omitempty"`
-	HipaaEnabled                 bool               `json:"hipaaEnabled,omitempty"`
-	ClientMessages               []string           `json:"clientMessages,omitempty"`
-	ServerMessages               []string           `json:"serverMessages,omitempty"`
-	BackgroundSound              string             `json:"backgroundSound,omitempty"`
-	BackgroundDenoising          bool               `json:"backgroundDenoisingEnabled,omitempty"`
-	ModelOutputEnabled           bool               `json:"modelOutputInMessagesEnabled,omitempty"`
-	Language                     string             `json:"language,omitempty"`
-	ForwardingPhoneNumber        string             `json:"forwardingPhoneNumber,omitempty"`
-	InterruptionsEnabled         bool               `json:"interruptionsEnabled,omitempty"`
-	EndCallFunctionEnabled       bool               `json:"endCallFunctionEnabled,omitempty"`
-	DialKeypadFunctionEnabled    bool               `json:"dialKeypadFunctionEnabled,omitempty"`
-	FillersEnabled               bool               `json:"fillersEnabled,omitempty"`
-	SilenceTimeoutSeconds        *float64           `json:"silenceTimeoutSeconds,omitempty"`
-	ResponseDelaySeconds         *float64           `json:"responseDelaySeconds,omitempty"`
-	NumWordsToInterruptAssistant *int64             `json:"numWordsToInterruptAssistant,omitempty"`
-	LiveTranscriptsEnabled       *bool              `json:"liveTranscriptsEnabled,omitempty"`
-	Keywords                     []string           `json:"keywords,omitempty"`
-	Voice                        *Voice             `json:"voice,omitempty"`
-	Model                        *Model             `json:"model,omitempty"`
-	RecordingEnabled             bool               `json:"recordingEnabled,omitempty"`
-	FirstMessage                 string             `json:"firstMessage,omitempty"`
-	VoicemailMessage             string             `json:"voicemailMessage,omitempty"`
-	EndCallMessage               string             `json:"endCallMessage,omitempty"`
-	Transcriber                  *Transcriber       `json:"transcriber,omitempty"`
-	EndCallPhrases               []string           `json:"endCallPhrases,omitempty"`
-	MaxDurationSeconds           int64              `json:"maxDurationSeconds,omitempty"`
-	AnalysisPlan                 *AnalysisPlan      `json:"analysisPlan,omitempty"`
-	MessagePlan                  *MessagePlan       `json:"messagePlan,omitempty"`
-	StartSpeakingPlan            *StartSpeakingPlan `json:"startSpeakingPlan,omitempty"`
-	StopSpeakingPlan             *StopSpeakingPlan  `json:"stopSpeakingPlan,omitempty"`
-	Server                       *Server            `json:"server,omitempty"`
-	ArtifactPlan                 *ArtifactPlan      `json:"artifactPlan,omitempty"`
+	Name                         string                         `json:"name"`
+	FirstMessageMode             Nullable[string]               `json:"firstMessageMode,omitzero"`
+	HipaaEnabled                 Nullable[bool]                 `json:"hipaaEnabled,omitzero"`
+	ClientMessages               Nullable[[]string]             `json:"clientMessages,omitzero"`
+	ServerMessages               Nullable[[]string]             `json:"serverMessages,omitzero"`
+	BackgroundSound              Nullable[string]               `json:"backgroundSound,omitzero"`
+	BackgroundDenoising          Nullable[bool]                 `json:"backgroundDenoisingEnabled,omitzero"`
+	ModelOutputEnabled           Nullable[bool]                 `json:"modelOutputInMessagesEnabled,omitzero"`
+	Language                     Nullable[string]               `json:"language,omitzero"`
+	ForwardingPhoneNumber        Nullable[string]               `json:"forwardingPhoneNumber,omitzero"`
+	InterruptionsEnabled         Nullable[bool]                 `json:"interruptionsEnabled,omitzero"`
+	EndCallFunctionEnabled       Nullable[bool]                 `json:"endCallFunctionEnabled,omitzero"`
+	DialKeypadFunctionEnabled    Nullable[bool]                 `json:"dialKeypadFunctionEnabled,omitzero"`
+	FillersEnabled               Nullable[bool]                 `json:"fillersEnabled,omitzero"`
+	SilenceTimeoutSeconds        Nullable[float64]              `json:"silenceTimeoutSeconds,omitzero"`
+	ResponseDelaySeconds         Nullable[float64]              `json:"responseDelaySeconds,omitzero"`
+	NumWordsToInterruptAssistant Nullable[int64]                `json:"numWordsToInterruptAssistant,omitzero"`
+	LiveTranscriptsEnabled       Nullable[bool]                 `json:"liveTranscriptsEnabled,omitzero"`
+	Keywords                     Nullable[[]string]             `json:"keywords,omitzero"`
+	Voice                        Nullable[*Voice]               `json:"voice,omitzero"`
+	Model                        Nullable[*Model]               `json:"model,omitzero"`
+	RecordingEnabled             Nullable[bool]                 `json:"recordingEnabled,omitzero"`
+	FirstMessage                 Nullable[string]               `json:"firstMessage,omitzero"`
+	VoicemailMessage             Nullable[string]               `json:"voicemailMessage,omitzero"`
+	EndCallMessage               Nullable[string]               `json:"endCallMessage,omitzero"`
+	Transcriber                  Nullable[*Transcriber]         `json:"transcriber,omitzero"`
+	EndCallPhrases               Nullable[[]string]             `json:"endCallPhrases,omitzero"`
+	MaxDurationSeconds           Nullable[int64]                `json:"maxDurationSeconds,omitzero"`
+	AnalysisPlan                 Nullable[*AnalysisPlanRequest] `json:"analysisPlan,omitzero"`
+	MessagePlan                  Nullable[*MessagePlan]         `json:"messagePlan,omitzero"`
+	StartSpeakingPlan            Nullable[*StartSpeakingPlan]   `json:"startSpeakingPlan,omitzero"`
+	StopSpeakingPlan             Nullable[*StopSpeakingPlan]    `json:"stopSpeakingPlan,omitzero"`
+	Server                       Nullable[*Server]              `json:"server,omitzero"`
+	ArtifactPlan                 Nullable[*ArtifactPlanRequest] `json:"artifactPlan,omitzero"`
+	VoicemailDetection           Nullable[*VoicemailDetection]  `json:"voicemailDetection,omitzero"`
+	KeypadInputPlan              Nullable[*KeypadInputPlan]     `json:"keypadInputPlan,omitzero"`
+	Hooks                        Nullable[[]Hook]               `json:"hooks,omitzero"`
 }
 
 // Assistant struct.
 type Assistant struct {
-	ID                           string             `json:"id,omitempty"`
-	OrgID                        string             `json:"orgId,omitempty"`
-	Name                         string             `json:"name,omitempty"`
-	FirstMessageMode             string             `json:"firstMessageMode,omitempty"`
-	HipaaEnabled                 bool               `json:"hipaaEnabled,omitempty"`
-	BackgroundSound              string             `json:"backgroundSound,omitempty"`
-	BackgroundDenoising          bool               `json:"backgroundDenoisingEnabled,omitempty"`
-	ModelOutputEnabled           bool               `json:"modelOutputInMessagesEnabled,omitempty"`
-	Language                     string             `json:"language,omitempty"`
-	ForwardingPhoneNumber        string             `json:"forwardingPhoneNumber,omitempty"`
-	InterruptionsEnabled         bool               `json:"interruptionsEnabled,omitempty"`
-	EndCallFunctionEnabled       bool               `json:"endCallFunctionEnabled,omitempty"`
-	DialKeypadFunctionEnabled    bool               `json:"dialKeypadFunctionEnabled,omitempty"`
-	FillersEnabled               bool               `json:"fillersEnabled,omitempty"`
-	SilenceTimeoutSeconds        *float64           `json:"silenceTimeoutSeconds,omitempty"`
-	ResponseDelaySeconds         *float64           `json:"responseDelaySeconds,omitempty"`
-	NumWordsToInterruptAssistant *int64             `json:"numWordsToInterruptAssistant,omitempty"`
-	LiveTranscriptsEnabled       *bool              `json:"liveTranscriptsEnabled,omitempty"`
-	Keywords                     []string           `json:"keywords,omitempty"`
-	ParentID                     *string            `json:"parentId,omitempty"`
-	Voice                        *Voice             `json:"voice,omitempty"`
-	Model                        *Model             `json:"model,omitempty"`
-	RecordingEnabled             bool               `json:"recordingEnabled,omitempty"`
-	FirstMessage                 string             `json:"firstMessage,omitempty"`
-	VoicemailMessage             string             `json:"voicemailMessage,omitempty"`
-	EndCallMessage               string             `json:"endCallMessage,omitempty"`
-	Transcriber                  *Transcriber       `json:"transcriber,omitempty"`
-	ClientMessages               []string           `json:"clientMessages,omitempty"`
-	ServerMessages               []string           `json:"serverMessages,omitempty"`
-	EndCallPhrases               []string           `json:"endCallPhrases,omitempty"`
-	MaxDurationSeconds           int64              `json:"maxDurationSeconds,omitempty"`
-	AnalysisPlan                 *AnalysisPlan      `json:"analysisPlan,omitempty"`
-	MessagePlan                  *MessagePlan       `json:"messagePlan,omitempty"`
-	StartSpeakingPlan            *StartSpeakingPlan `json:"startSpeakingPlan,omitempty"`
-	StopSpeakingPlan             *StopSpeakingPlan  `json:"StopSpeakingPlan,omitempty"`
-	Server                       *Server            `json:"server,omitempty"`
-	ArtifactPlan                 *ArtifactPlan      `json:"artifactPlan,omitempty"`
+	ID                           string              `json:"id,omitempty"`
+	OrgID                        string              `json:"orgId,omitempty"`
+	CreatedAt                    string              `json:"createdAt,omitempty"`
+	UpdatedAt                    string              `json:"updatedAt,omitempty"`
+	Name                         string              `json:"name,omitempty"`
+	FirstMessageMode             *string             `json:"firstMessageMode,omitempty"`
+	HipaaEnabled                 *bool               `json:"hipaaEnabled,omitempty"`
+	BackgroundSound              *string             `json:"backgroundSound,omitempty"`
+	BackgroundDenoising          *bool               `json:"backgroundDenoisingEnabled,omitempty"`
+	ModelOutputEnabled           *bool               `json:"modelOutputInMessagesEnabled,omitempty"`
+	Language                     *string             `json:"language,omitempty"`
+	ForwardingPhoneNumber        *string             `json:"forwardingPhoneNumber,omitempty"`
+	InterruptionsEnabled         *bool               `json:"interruptionsEnabled,omitempty"`
+	EndCallFunctionEnabled       *bool               `json:"endCallFunctionEnabled,omitempty"`
+	DialKeypadFunctionEnabled    *bool               `json:"dialKeypadFunctionEnabled,omitempty"`
+	FillersEnabled               *bool               `json:"fillersEnabled,omitempty"`
+	SilenceTimeoutSeconds        *float64            `json:"silenceTimeoutSeconds,omitempty"`
+	ResponseDelaySeconds         *float64            `json:"responseDelaySeconds,omitempty"`
+	NumWordsToInterruptAssistant *int64              `json:"numWordsToInterruptAssistant,omitempty"`
+	LiveTranscriptsEnabled       *bool               `json:"liveTranscriptsEnabled,omitempty"`
+	Keywords                     []string            `json:"keywords,omitempty"`
+	ParentID                     *string             `json:"parentId,omitempty"`
+	Voice                        *Voice              `json:"voice,omitempty"`
+	Model                        *Model              `json:"model,omitempty"`
+	RecordingEnabled             *bool               `json:"recordingEnabled,omitempty"`
+	FirstMessage                 *string             `json:"firstMessage,omitempty"`
+	VoicemailMessage             *string             `json:"voicemailMessage,omitempty"`
+	EndCallMessage               *string             `json:"endCallMessage,omitempty"`
+	Transcriber                  *Transcriber        `json:"transcriber,omitempty"`
+	ClientMessages               []string            `json:"clientMessages,omitempty"`
+	ServerMessages               []string            `json:"serverMessages,omitempty"`
+	EndCallPhrases               []string            `json:"endCallPhrases,omitempty"`
+	MaxDurationSeconds           *int64              `json:"maxDurationSeconds,omitempty"`
+	AnalysisPlan                 *AnalysisPlan       `json:"analysisPlan,omitempty"`
+	MessagePlan                  *MessagePlan        `json:"messagePlan,omitempty"`
+	StartSpeakingPlan            *StartSpeakingPlan  `json:"startSpeakingPlan,omitempty"`
+	StopSpeakingPlan             *StopSpeakingPlan   `json:"stopSpeakingPlan,omitempty"`
+	Server                       *Server             `json:"server,omitempty"`
+	ArtifactPlan                 *ArtifactPlan       `json:"artifactPlan,omitempty"`
+	VoicemailDetection           *VoicemailDetection `json:"voicemailDetection,omitempty"`
+	KeypadInputPlan              *KeypadInputPlan    `json:"keypadInputPlan,omitempty"`
+	Hooks                        []Hook              `json:"hooks,omitempty"`
+}
+
+// VoicemailDetection configures how voicemail is detected on outbound calls.
+// MachineDetectionTimeout applies to the twilio provider, BackoffPlan to the
+// others.
+type VoicemailDetection struct {
+	Provider                string                         `json:"provider"`
+	MachineDetectionTimeout *int64                         `json:"machineDetectionTimeout,omitempty"`
+	BackoffPlan             *VoicemailDetectionBackoffPlan `json:"backoffPlan,omitempty"`
+}
+
+// VoicemailDetectionBackoffPlan controls when and how often voicemail
+// detection is retried during a call.
+type VoicemailDetectionBackoffPlan struct {
+	StartAtSeconds   *float64 `json:"startAtSeconds,omitempty"`
+	FrequencySeconds *float64 `json:"frequencySeconds,omitempty"`
+	MaxRetries       *int64   `json:"maxRetries,omitempty"`
+}
+
+// KeypadInputPlan configures how DTMF keypad input from the caller is
+// collected.
+type KeypadInputPlan struct {
+	Enabled        *bool    `json:"enabled,omitempty"`
+	TimeoutSeconds *float64 `json:"timeoutSeconds,omitempty"`
+	Delimiters     []string `json:"delimiters,omitempty"`
+}
+
+// Hook runs actions when a call event occurs.
+type Hook struct {
+	On      string       `json:"on"`
+	Do      []HookAction `json:"do"`
+	Options *HookOptions `json:"options,omitempty"`
+}
+
+// HookAction is a single action run by a hook. Exact and Prompt apply to say
+// actions, Destination to transfer actions.
+type HookAction struct {
+	Type        string       `json:"type"`
+	Exact       *string      `json:"exact,omitempty"`
+	Prompt      *string      `json:"prompt,omitempty"`
+	Destination *Destination `json:"destination,omitempty"`
+}
+
+// HookOptions tunes when a customer.speech.timeout hook fires.
+type HookOptions struct {
+	TimeoutSeconds   *float64 `json:"timeoutSeconds,omitempty"`
+	TriggerMaxCount  *int64   `json:"triggerMaxCount,omitempty"`
+	TriggerResetMode *string  `json:"triggerResetMode,omitempty"`
 }
 
+// ArtifactPlan configures the artifacts stored for each call.
 type ArtifactPlan struct {
-	RecordingFormat string `json:"recordingFormat,omitempty"`
+	RecordingFormat       string          `json:"recordingFormat,omitempty"`
+	RecordingPath         *string         `json:"recordingPath,omitempty"`
+	VideoRecordingEnabled *bool           `json:"videoRecordingEnabled,omitempty"`
+	PcapEnabled           *bool           `json:"pcapEnabled,omitempty"`
+	TranscriptPlan        *TranscriptPlan `json:"transcriptPlan,omitempty"`
+}
+
+// ArtifactPlanRequest is the ArtifactPlan sent to the API. The API merges it
+// into the stored plan, so its settings are Nullable and a setting removed
+// from configuration is cleared with an explicit null.
+type ArtifactPlanRequest struct {
+	RecordingFormat       string                    `json:"recordingFormat,omitempty"`
+	RecordingPath         Nullable[string]          `json:"recordingPath,omitzero"`
+	VideoRecordingEnabled Nullable[bool]            `json:"videoRecordingEnabled,omitzero"`
+	PcapEnabled           Nullable[bool]            `json:"pcapEnabled,omitzero"`
+	TranscriptPlan        Nullable[*TranscriptPlan] `json:"transcriptPlan,omitzero"`
 }
 
+// TranscriptPlan configures the stored call transcript.
+type TranscriptPlan struct {
+	Enabled       *bool   `json:"enabled,omitempty"`
+	AssistantName *string `json:"assistantName,omitempty"`
+	UserName      *string `json:"userName,omitempty"`
+}
+
+// Server is the webhook endpoint that receives events and tool calls for an
+// assistant, tool or phone number.
 type Server struct {
-	URL            string `json:"url,omitempty"`
-	Secret         string `json:"secret,omitempty"`
-	TimeoutSeconds int64  `json:"timeoutSeconds,omitempty"`
+	URL            string             `json:"url,omitempty"`
+	Secret         string             `json:"secret,omitempty"`
+	TimeoutSeconds int64              `json:"timeoutSeconds,omitempty"`
+	Headers        map[string]string  `json:"headers,omitempty"`
+	BackoffPlan    *ServerBackoffPlan `json:"backoffPlan,omitempty"`
+}
+
+// ServerBackoffPlan controls how failed webhook requests are retried.
+type ServerBackoffPlan struct {
+	Type             string   `json:"type"`
+	MaxRetries       *int64   `json:"maxRetries,omitempty"`
+	BaseDelaySeconds *float64 `json:"baseDelaySeconds,omitempty"`
 }
 
 // StopSpeakingPlan struct.
 type StopSpeakingPlan struct {
-	NumWords       float64 `json:"numWords,omitempty"`
-	VoiceSeconds   float64 `json:"voiceSeconds,omitempty"`
-	BackoffSeconds float64 `json:"backoffSeconds,omitempty"`
+	NumWords       *float64 `json:"numWords,omitempty"`
+	VoiceSeconds   *float64 `json:"voiceSeconds,omitempty"`
+	BackoffSeconds *float64 `json:"backoffSeconds,omitempty"`
 }
 
 // StartSpeakingPlan struct.
 type StartSpeakingPlan struct {
-	WaitSeconds                  float64                       `json:"waitSeconds,omitempty"`
-	SmartEndpointingEnabled      bool                          `json:"smartEndpointingEnabled,omitempty"`
+	WaitSeconds                  *float64                      `json:"waitSeconds,omitempty"`
+	SmartEndpointingEnabled      *bool                         `json:"smartEndpointingEnabled,omitempty"`
 	TranscriptionEndpointingPlan *TranscriptionEndpointingPlan `json:"transcriptionEndpointingPlan,omitempty"`
 }
 
 // TranscriptionEndpointingPlan struct.
 type TranscriptionEndpointingPlan struct {
-	OnPunctuationSeconds   float64 `json:"onPunctuationSeconds,omitempty"`
-	OnNoPunctuationSeconds float64 `json:"onNoPunctuationSeconds,omitempty"`
-	OnNumberSeconds        float64 `json:"onNumberSeconds,omitempty"`
+	OnPunctuationSeconds   *float64 `json:"onPunctuationSeconds,omitempty"`
+	OnNoPunctuationSeconds *float64 `json:"onNoPunctuationSeconds,omitempty"`
+	OnNumberSeconds        *float64 `json:"onNumberSeconds,omitempty"`
 }
 
 // Voice struct.
 type Voice struct {
-	Model           string  `json:"model,omitempty"`
-	VoiceID         string  `json:"voiceId,omitempty"`
-	Provider        string  `json:"provider,omitempty"`
-	Stability       float64 `json:"stability,omitempty"`
-	SimilarityBoost float64 `json:"similarityBoost,omitempty"`
+	Model           string             `json:"model,omitempty"`
+	VoiceID         string             `json:"voiceId,omitempty"`
+	Provider        string             `json:"provider,omitempty"`
+	Stability       *float64           `json:"stability,omitempty"`
+	SimilarityBoost *float64           `json:"similarityBoost,omitempty"`
+	FallbackPlan    *VoiceFallbackPlan `json:"fallbackPlan,omitempty"`
+
+	Speed                     *float64   `json:"speed,omitempty"`
+	Style                     *float64   `json:"style,omitempty"`
+	Emotion                   *string    `json:"emotion,omitempty"`
+	Language                  *string    `json:"language,omitempty"`
+	OptimizeStreamingLatency  *int64     `json:"optimizeStreamingLatency,omitempty"`
+	InputPreprocessingEnabled *bool      `json:"inputPreprocessingEnabled,omitempty"`
+	ChunkPlan                 *ChunkPlan `json:"chunkPlan,omitempty"`
+}
+
+// ChunkPlan controls how model output is split into chunks before it is sent
+// to the voice provider.
+type ChunkPlan struct {
+	Enabled               *bool    `json:"enabled,omitempty"`
+	MinCharacters         *int64   `json:"minCharacters,omitempty"`
+	PunctuationBoundaries []string `json:"punctuationBoundaries,omitempty"`
+}
+
+// VoiceFallbackPlan lists the voices tried, in order, when the primary voice
+// provider fails.
+type VoiceFallbackPlan struct {
+	Voices []Voice `json:"voices"`
 }
 
 // Model struct.
 type Model struct {
-	Model         string         `json:"model"`
-	SystemPrompt  string         `json:"systemPrompt"`
-	Provider      string         `json:"provider"`
-	MaxTokens     int64          `json:"maxTokens,omitempty"`
-	Temperature   float64        `json:"temperature,omitempty"`
-	ToolIDs       []string       `json:"toolIds,omitempty"`
-	Messages      []Message      `json:"messages,omitempty"`
-	KnowledgeBase *KnowledgeBase `json:"knowledgeBase,omitempty"`
+	Model string `json:"model"`
+	// SystemPrompt is the legacy form of a leading system message. The
+	// provider sends the system prompt in Messages and only reads this field.
+	SystemPrompt   *string         `json:"systemPrompt,omitempty"`
+	Provider       string          `json:"provider,omitempty"`
+	MaxTokens      *int64          `json:"maxTokens,omitempty"`
+	Temperature    *float64        `json:"temperature,omitempty"`
+	ToolIDs        []string        `json:"toolIds,omitempty"`
+	Tools          []ModelTool     `json:"tools,omitempty"`
+	FallbackModels []FallbackModel `json:"fallbackModels,omitempty"`
+	Messages       []Message       `json:"messages,omitempty"`
+	KnowledgeBase  *KnowledgeBase  `json:"knowledgeBase,omitempty"`
+}
+
+// FallbackModel is a model tried, in order, when the primary model fails.
+type FallbackModel struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
 }
 
 // Message struct.
@@ -139,28 +270,78 @@ type Message struct {
 
 // KnowledgeBase struct.
 type KnowledgeBase struct {
-	TopK     int64    `json:"topK"`
-	FileIDs  []string `json:"fileIds"`
-	Provider string   `json:"provider"`
+	TopK     *int64   `json:"topK,omitempty"`
+	FileIDs  []string `json:"fileIds,omitempty"`
+	Provider string   `json:"provider,omitempty"`
 }
 
 // Transcriber struct.
 type Transcriber struct {
-	Model    string `json:"model"`
-	Language string `json:"language"`
-	Provider string `json:"provider"`
+	Model        *string                  `json:"model,omitempty"`
+	Language     *string                  `json:"language,omitempty"`
+	Provider     string                   `json:"provider"`
+	FallbackPlan *TranscriberFallbackPlan `json:"fallbackPlan,omitempty"`
+}
+
+// TranscriberFallbackPlan lists the transcribers tried, in order, when the
+// primary transcriber provider fails.
+type TranscriberFallbackPlan struct {
+	Transcribers []Transcriber `json:"transcribers"`
 }
 
 // AnalysisPlan struct.
 type AnalysisPlan struct {
-	SummaryPrompt           string                `json:"summaryPrompt"`
-	StructuredDataPrompt    string                `json:"structuredDataPrompt"`
-	StructuredDataSchema    *StructuredDataSchema `json:"structuredDataSchema"`
-	SuccessEvaluationPrompt string                `json:"successEvaluationPrompt"`
-	SuccessEvaluationRubric string                `json:"successEvaluationRubric"`
+	SummaryPrompt           *string                `json:"summaryPrompt,omitempty"`
+	StructuredDataPrompt    *string                `json:"structuredDataPrompt,omitempty"`
+	StructuredDataSchema    json.RawMessage        `json:"structuredDataSchema,omitempty"`
+	SuccessEvaluationPrompt *string                `json:"successEvaluationPrompt,omitempty"`
+	SuccessEvaluationRubric *string                `json:"successEvaluationRubric,omitempty"`
+	SummaryPlan             *SummaryPlan           `json:"summaryPlan,omitempty"`
+	StructuredDataPlan      *StructuredDataPlan    `json:"structuredDataPlan,omitempty"`
+	SuccessEvaluationPlan   *SuccessEvaluationPlan `json:"successEvaluationPlan,omitempty"`
+}
+
+// AnalysisPlanRequest is the AnalysisPlan sent to the API. Like
+// ArtifactPlanRequest, its settings are Nullable so that removed prompts and
+// plans are cleared.
+type AnalysisPlanRequest struct {
+	SummaryPrompt           Nullable[string]                 `json:"summaryPrompt,omitzero"`
+	StructuredDataPrompt    Nullable[string]                 `json:"structuredDataPrompt,omitzero"`
+	StructuredDataSchema    Nullable[json.RawMessage]        `json:"structuredDataSchema,omitzero"`
+	SuccessEvaluationPrompt Nullable[string]                 `json:"successEvaluationPrompt,omitzero"`
+	SuccessEvaluationRubric Nullable[string]                 `json:"successEvaluationRubric,omitzero"`
+	SummaryPlan             Nullable[*SummaryPlan]           `json:"summaryPlan,omitzero"`
+	StructuredDataPlan      Nullable[*StructuredDataPlan]    `json:"structuredDataPlan,omitzero"`
+	SuccessEvaluationPlan   Nullable[*SuccessEvaluationPlan] `json:"successEvaluationPlan,omitzero"`
+}
+
+// SummaryPlan configures the summary generated after a call.
+type SummaryPlan struct {
+	Messages       []Message `json:"messages,omitempty"`
+	Enabled        *bool     `json:"enabled,omitempty"`
+	TimeoutSeconds *float64  `json:"timeoutSeconds,omitempty"`
+}
+
+// StructuredDataPlan configures the structured data extracted after a call.
+// Schema is raw JSON, like AnalysisPlan.StructuredDataSchema.
+type StructuredDataPlan struct {
+	Messages       []Message       `json:"messages,omitempty"`
+	Enabled        *bool           `json:"enabled,omitempty"`
+	Schema         json.RawMessage `json:"schema,omitempty"`
+	TimeoutSeconds *float64        `json:"timeoutSeconds,omitempty"`
+}
+
+// SuccessEvaluationPlan configures how the call outcome is evaluated.
+type SuccessEvaluationPlan struct {
+	Rubric         *string   `json:"rubric,omitempty"`
+	Messages       []Message `json:"messages,omitempty"`
+	Enabled        *bool     `json:"enabled,omitempty"`
+	TimeoutSeconds *float64  `json:"timeoutSeconds,omitempty"`
 }
 
-// StructuredDataSchema struct.
+// StructuredDataSchema is the flat form of a structured data schema. The
+// analysis plan carries the schema as raw JSON so that nested schemas
+// round-trip unchanged.
 type StructuredDataSchema struct {
 	Type       string               `json:"type"`
 	Properties map[string]*Property `json:"properties"`
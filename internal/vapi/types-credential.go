@@ -0,0 +1,12 @@
+package vapi
+
+// Credential holds the fields shared by every provider credential, as
+// returned by list requests.
+type Credential struct {
+	ID        string `json:"id"`
+	OrgID     string `json:"orgId"`
+	Provider  string `json:"provider"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
@@ -37,6 +37,9 @@ func (fr *FileResponse) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &temp); err != nil {
 		return err
 	}
+	if len(temp.Bytes) == 0 {
+		return nil
+	}
 
 	var bytesValue interface{}
 	if err := json.Unmarshal(temp.Bytes, &bytesValue); err != nil {
@@ -54,6 +57,8 @@ func (fr *FileResponse) UnmarshalJSON(data []byte) error {
 		fr.Bytes = parsed
 	case int64:
 		fr.Bytes = v
+	case nil:
+		fr.Bytes = 0
 	default:
 		return fmt.Errorf("unexpected type for bytes: %T", v)
 	}
@@ -9,6 +9,7 @@ type ImportTwilioRequest struct {
 	TwilioAuthToken  string               `json:"twilioAuthToken"`
 	AssistantID      string               `json:"assistantId,omitempty"`
 	Fallback         *FallbackDestination `json:"fallbackDestination,omitempty"`
+	Server           Nullable[*Server]    `json:"server,omitzero"`
 }
 
 // FallbackDestination struct.
@@ -34,4 +35,19 @@ type TwilioPhoneNumber struct {
 	Provider         string               `json:"provider"`
 	AssistantID      string               `json:"assistantId"`
 	Fallback         *FallbackDestination `json:"fallbackDestination,omitempty"`
+	Server           *Server              `json:"server,omitempty"`
+}
+
+// PhoneNumber holds the fields shared by every phone number provider, as
+// returned by list requests.
+type PhoneNumber struct {
+	ID           string `json:"id"`
+	OrgID        string `json:"orgId"`
+	Provider     string `json:"provider"`
+	Name         string `json:"name"`
+	Number       string `json:"number"`
+	AssistantID  string `json:"assistantId"`
+	CredentialID string `json:"credentialId"`
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
 }
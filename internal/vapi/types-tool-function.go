@@ -1,7 +1,10 @@
 package vapi
 
+import "encoding/json"
+
 // ToolFunctionRequest struct.
 type ToolFunctionRequest struct {
+	Messages     []ToolMessage `json:"messages,omitempty"`
 	Destinations []Destination `json:"destinations,omitempty"`
 	Server       *Server       `json:"server"`
 	Function     Function      `json:"function"`
@@ -9,22 +12,54 @@ type ToolFunctionRequest struct {
 	Async        bool          `json:"async"`
 }
 
+// UpdateToolFunctionRequest is the PATCH body for a function tool. Only the
+// fields that changed are set; the API does not allow changing the tool type.
+type UpdateToolFunctionRequest struct {
+	Messages     Nullable[[]ToolMessage] `json:"messages,omitzero"`
+	Destinations Nullable[[]Destination] `json:"destinations,omitzero"`
+	Server       Nullable[*Server]       `json:"server,omitzero"`
+	Function     *Function               `json:"function,omitempty"`
+	Async        Nullable[bool]          `json:"async,omitzero"`
+}
+
+// Destination is where a transferCall tool forwards the call: a phone number,
+// a SIP URI or another assistant, depending on Type.
 type Destination struct {
-	Type                   string `json:"type"`
-	Number                 string `json:"number"`
-	Extension              string `json:"extension,omitempty"`
-	Message                string `json:"message,omitempty"`
-	Description            string `json:"description,omitempty"`
-	NumberE164CheckEnabled bool   `json:"numberE164CheckEnabled"`
+	Type                   string            `json:"type"`
+	Number                 string            `json:"number,omitempty"`
+	Extension              string            `json:"extension,omitempty"`
+	CallerID               string            `json:"callerId,omitempty"`
+	SipURI                 string            `json:"sipUri,omitempty"`
+	SipHeaders             map[string]string `json:"sipHeaders,omitempty"`
+	AssistantName          string            `json:"assistantName,omitempty"`
+	TransferMode           string            `json:"transferMode,omitempty"`
+	Message                string            `json:"message,omitempty"`
+	Description            string            `json:"description,omitempty"`
+	NumberE164CheckEnabled *bool             `json:"numberE164CheckEnabled,omitempty"`
+	TransferPlan           *TransferPlan     `json:"transferPlan,omitempty"`
+}
+
+// TransferPlan controls how a call is handed over to a number or SIP
+// destination. Warm transfers can say Message or a summary of the call to the
+// operator before connecting the customer.
+type TransferPlan struct {
+	Mode        string       `json:"mode"`
+	Message     string       `json:"message,omitempty"`
+	SipVerb     string       `json:"sipVerb,omitempty"`
+	SummaryPlan *SummaryPlan `json:"summaryPlan,omitempty"`
 }
 
+// Function describes a function the model can call. Parameters is the raw
+// JSON schema of its arguments, so nested schemas round-trip unchanged.
 type Function struct {
 	Description string          `json:"description"`
 	Async       bool            `json:"async,omitempty"`
 	Name        string          `json:"name,omitempty"`
-	Parameters  *FunctionParams `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
 }
 
+// FunctionParams is the flat form of a function's parameters schema.
 type FunctionParams struct {
 	Type       string              `json:"type"`
 	Properties map[string]Property `json:"properties,omitempty"`
@@ -39,35 +74,22 @@ type Property struct {
 
 // ToolFunctionResponse struct.
 type ToolFunctionResponse struct {
-	ID        string           `json:"id"`
-	CreatedAt string           `json:"createdAt"`
-	UpdatedAt string           `json:"updatedAt"`
-	Type      string           `json:"type"`
-	Function  ResponseFunction `json:"function"`
-	OrgID     string           `json:"orgId"`
-	Server    ResponseServer   `json:"server"`
-	Async     bool             `json:"async"`
+	ID           string           `json:"id"`
+	CreatedAt    string           `json:"createdAt"`
+	UpdatedAt    string           `json:"updatedAt"`
+	Type         string           `json:"type"`
+	Function     ResponseFunction `json:"function"`
+	OrgID        string           `json:"orgId"`
+	Server       *Server          `json:"server,omitempty"`
+	Async        bool             `json:"async"`
+	Messages     []ToolMessage    `json:"messages,omitempty"`
+	Destinations []Destination    `json:"destinations,omitempty"`
 }
 
 type ResponseFunction struct {
-	Name        string                 `json:"name"`
-	Async       bool                   `json:"async"`
-	Description string                 `json:"description"`
-	Parameters  ResponseFunctionParams `json:"parameters"`
-}
-
-type ResponseFunctionParams struct {
-	Type       string                      `json:"type"`
-	Properties map[string]ResponseProperty `json:"properties"`
-	Required   []string                    `json:"required"`
-}
-
-type ResponseProperty struct {
-	Description string   `json:"description"`
-	Type        string   `json:"type"`
-	Enum        []string `json:"enum,omitempty"`
-}
-
-type ResponseServer struct {
-	URL string `json:"url"`
+	Name        string          `json:"name"`
+	Async       bool            `json:"async"`
+	Description string          `json:"description"`
+	Strict      *bool           `json:"strict,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
 }
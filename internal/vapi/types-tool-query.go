@@ -1,10 +1,12 @@
 package vapi
 
-// ToolQueryFunctionRequest struct.
+// ToolQueryFunctionRequest struct. Messages is Nullable so that removed
+// messages can be cleared on update.
 type ToolQueryFunctionRequest struct {
-	Function       Function          `json:"function"`
-	KnowledgeBases []TQKnowledgeBase `json:"knowledgeBases"`
-	Type           string            `json:"type,omitempty"`
+	Function       Function                `json:"function"`
+	KnowledgeBases []TQKnowledgeBase       `json:"knowledgeBases"`
+	Type           string                  `json:"type,omitempty"`
+	Messages       Nullable[[]ToolMessage] `json:"messages,omitzero"`
 }
 
 type ToolQueryFunctionResponse struct {
@@ -15,6 +17,7 @@ type ToolQueryFunctionResponse struct {
 	OrgID          string            `json:"orgId"`
 	Function       Function          `json:"function"`
 	KnowledgeBases []TQKnowledgeBase `json:"knowledgeBases"`
+	Messages       []ToolMessage     `json:"messages,omitempty"`
 }
 
 type TQKnowledgeBase struct {
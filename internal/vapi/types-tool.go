@@ -0,0 +1,65 @@
+package vapi
+
+import "encoding/json"
+
+// Tool holds the fields shared by every tool type, as returned by list
+// requests.
+type Tool struct {
+	ID        string   `json:"id"`
+	OrgID     string   `json:"orgId"`
+	Type      string   `json:"type"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+	Function  Function `json:"function"`
+}
+
+// ToolRequest is the create and update body of the built-in tool types, such
+// as transferCall, endCall, dtmf and sms. Type is only sent on create; the API
+// does not allow changing it.
+type ToolRequest struct {
+	Type         string                  `json:"type,omitempty"`
+	Messages     Nullable[[]ToolMessage] `json:"messages,omitzero"`
+	Function     Nullable[*Function]     `json:"function,omitzero"`
+	Destinations Nullable[[]Destination] `json:"destinations,omitzero"`
+}
+
+// ToolResponse is a built-in tool as returned by the API.
+type ToolResponse struct {
+	ID           string        `json:"id"`
+	OrgID        string        `json:"orgId"`
+	Type         string        `json:"type"`
+	CreatedAt    string        `json:"createdAt"`
+	UpdatedAt    string        `json:"updatedAt"`
+	Function     *Function     `json:"function,omitempty"`
+	Messages     []ToolMessage `json:"messages,omitempty"`
+	Destinations []Destination `json:"destinations,omitempty"`
+}
+
+// ModelTool is a tool defined inline on an assistant model instead of being
+// referenced by ID.
+type ModelTool struct {
+	Type         string        `json:"type"`
+	Async        *bool         `json:"async,omitempty"`
+	Function     *Function     `json:"function,omitempty"`
+	Messages     []ToolMessage `json:"messages,omitempty"`
+	Destinations []Destination `json:"destinations,omitempty"`
+	Server       *Server       `json:"server,omitempty"`
+}
+
+// ToolMessage is spoken by the assistant at a point in a tool call's
+// lifecycle, such as request-start or request-failed.
+type ToolMessage struct {
+	Type               string                 `json:"type"`
+	Content            string                 `json:"content,omitempty"`
+	Conditions         []ToolMessageCondition `json:"conditions,omitempty"`
+	TimingMilliseconds *int64                 `json:"timingMilliseconds,omitempty"`
+}
+
+// ToolMessageCondition restricts a ToolMessage to tool calls whose param
+// compares to Value with Operator. The API accepts strings, numbers and
+// booleans as Value, so it is kept as raw JSON to preserve the type.
+type ToolMessageCondition struct {
+	Param    string          `json:"param"`
+	Operator string          `json:"operator"`
+	Value    json.RawMessage `json:"value"`
+}
@@ -2,6 +2,7 @@ package vapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,17 +10,46 @@ import (
 	"net/http"
 	"net/textproto"
 	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
+// DefaultBaseURL is the public Vapi API endpoint.
+const DefaultBaseURL = "https://api.vapi.ai"
+
 // APIClient handles communication with the remote provider.
 type APIClient struct {
 	BaseURL    string
 	Token      string
 	HTTPClient *http.Client
+	// UserAgent is sent with every request when set.
+	UserAgent string
+
+	// MaxRetries is the number of additional attempts made for retryable
+	// failures. Zero disables retries.
+	MaxRetries int
+	// RetryMinWait and RetryMaxWait bound the exponential backoff between
+	// attempts. Zero values fall back to DefaultRetryMinWait and
+	// DefaultRetryMaxWait.
+	RetryMinWait time.Duration
+	RetryMaxWait time.Duration
+
+	// RequestsPerSecond limits the sustained request rate across every
+	// caller sharing the client. Zero disables rate limiting.
+	RequestsPerSecond float64
+	// MaxConcurrentRequests caps the number of in-flight requests. Zero
+	// disables the cap.
+	MaxConcurrentRequests int
+
+	throttleOnce sync.Once
+	limiter      *rate.Limiter
+	slots        chan struct{}
 }
 
 // UploadData Uploads a file using multipart/form-data.
-func (c *APIClient) UploadData(fieldName, filename string, content []byte) ([]byte, int, error) {
+func (c *APIClient) UploadData(ctx context.Context, fieldName, filename string, content []byte) ([]byte, int, error) {
 	// Create a buffer to write our multipart data into
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -47,279 +77,235 @@ func (c *APIClient) UploadData(fieldName, filename string, content []byte) ([]by
 		return nil, 0, fmt.Errorf("error closing writer: %v", err)
 	}
 
-	// Create the HTTP request
-	req, err := http.NewRequest("POST", c.BaseURL+"/file", body)
-	if err != nil {
-		return nil, 0, fmt.Errorf("error creating request: %v", err)
+	// Send the request and return the response body and status code
+	return c.do(ctx, http.MethodPost, "file", writer.FormDataContentType(), body.Bytes())
+}
+
+// SendRequest sends a JSON request to the API. The request is bound to ctx, so
+// cancellation and deadlines abort the in-flight HTTP call. Non-2xx responses
+// are reported as *APIError.
+func (c *APIClient) SendRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, int, error) {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return nil, 0, fmt.Errorf("failed to encode request body: %w", err)
+		}
 	}
 
-	// Set the headers
-	req.Header.Set("Authorization", "Bearer "+c.Token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return c.do(ctx, method, endpoint, "application/json", buf.Bytes())
+}
 
-	// Send the request
+// do performs an HTTP request against endpoint, retrying transient failures
+// according to the client's retry settings. The payload is replayed on every
+// attempt.
+func (c *APIClient) do(ctx context.Context, method, endpoint, contentType string, payload []byte) ([]byte, int, error) {
 	httpClient := c.HTTPClient
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
 
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, 0, fmt.Errorf("error sending request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	responseData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("error reading response body: %v", err)
-	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+"/"+endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
 
-	// Return the response body and status code
-	return responseData, resp.StatusCode, nil
-}
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		req.Header.Set("Content-Type", contentType)
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
 
-func (c *APIClient) SendRequest(method, endpoint string, body interface{}) ([]byte, int, error) {
-	var buf bytes.Buffer
-	if body != nil {
-		if err := json.NewEncoder(&buf).Encode(body); err != nil {
-			return nil, 0, fmt.Errorf("failed to encode request body: %w", err)
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, 0, fmt.Errorf("request failed: %w", err)
 		}
-	}
 
-	req, err := http.NewRequest(method, c.BaseURL+"/"+endpoint, &buf)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			release()
+			if attempt < c.MaxRetries && ctx.Err() == nil && isIdempotent(method) {
+				if waitErr := sleepContext(ctx, c.backoff(attempt, nil)); waitErr == nil {
+					continue
+				}
+			}
+			return nil, 0, fmt.Errorf("request failed: %w", err)
+		}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
-	req.Header.Set("Content-Type", "application/json")
+		responseData, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		release()
+		if readErr != nil {
+			return nil, resp.StatusCode, fmt.Errorf("error reading response body: %w", readErr)
+		}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, 0, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
+		if attempt < c.MaxRetries && shouldRetry(method, resp.StatusCode) {
+			if err := sleepContext(ctx, c.backoff(attempt, resp.Header)); err != nil {
+				return responseData, resp.StatusCode, fmt.Errorf("request failed: %w", err)
+			}
+			continue
+		}
 
-	responseData, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, resp.StatusCode, fmt.Errorf("error reading response body: %w", readErr)
-	}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return responseData, resp.StatusCode, newAPIError(method, endpoint, resp, responseData)
+		}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return responseData, resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(responseData))
+		return responseData, resp.StatusCode, nil
 	}
-
-	return responseData, resp.StatusCode, nil
 }
 
-// GetFile retrieves the details of a specific phone number by ID.
-func (c *APIClient) GetFile(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
+// UploadFile uploads content as a new file and decodes the created entity.
+func (c *APIClient) UploadFile(ctx context.Context, filename string, content []byte) (*FileResponse, error) {
+	data, _, err := c.UploadData(ctx, "file", filename, content)
+	if err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("file/%s", id)
-	return c.SendRequest("GET", endpoint, nil)
+	return decode[FileResponse](FileEndpoint, data)
 }
 
-// DeleteFile deletes a specific phone number by ID.
-func (c *APIClient) DeleteFile(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("file/%s", id)
-	return c.SendRequest("DELETE", endpoint, nil)
+// GetFile retrieves the details of a specific file by ID.
+func (c *APIClient) GetFile(ctx context.Context, id string) (*FileResponse, error) {
+	return Get[FileResponse](ctx, c, FileEndpoint, id)
 }
 
-// ImportTwilioPhoneNumber requests the creation of a new phone number.
-func (c *APIClient) ImportTwilioPhoneNumber(requestData ImportTwilioRequest) ([]byte, int, error) {
-	return c.SendRequest("POST", "phone-number", requestData)
+// DeleteFile deletes a specific file by ID.
+func (c *APIClient) DeleteFile(ctx context.Context, id string) error {
+	return Delete(ctx, c, FileEndpoint, id)
 }
 
-// GetPhoneNumber retrieves the details of a specific phone number by ID.
-func (c *APIClient) GetPhoneNumber(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("phone-number/%s", id)
-	return c.SendRequest("GET", endpoint, nil)
+// ImportTwilioPhoneNumber requests the creation of a new phone number.
+func (c *APIClient) ImportTwilioPhoneNumber(ctx context.Context, requestData ImportTwilioRequest) (*TwilioPhoneNumber, error) {
+	return Create[TwilioPhoneNumber](ctx, c, PhoneNumberEndpoint, requestData)
 }
 
-// DeletePhoneNumber deletes a specific phone number by ID.
-func (c *APIClient) DeletePhoneNumber(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("phone-number/%s", id)
-	return c.SendRequest("DELETE", endpoint, nil)
+// GetTwilioPhoneNumber retrieves the details of a Twilio phone number by ID.
+func (c *APIClient) GetTwilioPhoneNumber(ctx context.Context, id string) (*TwilioPhoneNumber, error) {
+	return Get[TwilioPhoneNumber](ctx, c, PhoneNumberEndpoint, id)
 }
 
-// UpdatePhoneNumber updates a phone number by ID.
-func (c *APIClient) UpdatePhoneNumber(id string, requestData interface{}) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("phone-number/%s", id)
-	return c.SendRequest("PATCH", endpoint, requestData)
+// UpdateTwilioPhoneNumber updates a Twilio phone number by ID.
+func (c *APIClient) UpdateTwilioPhoneNumber(ctx context.Context, id string, requestData ImportTwilioRequest) (*TwilioPhoneNumber, error) {
+	return Update[TwilioPhoneNumber](ctx, c, PhoneNumberEndpoint, id, requestData)
 }
 
 // ImportSIPTrunkPhoneNumber requests the creation of a new phone number.
-func (c *APIClient) ImportSIPTrunkPhoneNumber(requestData ImportSIPTrunkPhoneNumberRequest) ([]byte, int, error) {
-	return c.SendRequest("POST", "phone-number", requestData)
+func (c *APIClient) ImportSIPTrunkPhoneNumber(ctx context.Context, requestData ImportSIPTrunkPhoneNumberRequest) (*ImportSIPTrunkPhoneNumberResponse, error) {
+	return Create[ImportSIPTrunkPhoneNumberResponse](ctx, c, PhoneNumberEndpoint, requestData)
 }
 
-// CreateToolQueryFunction method.
-func (c *APIClient) CreateToolQueryFunction(requestData ToolQueryFunctionRequest) ([]byte, int, error) {
-	return c.SendRequest("POST", "tool", requestData)
+// GetSIPTrunkPhoneNumber retrieves the details of a SIP trunk phone number by ID.
+func (c *APIClient) GetSIPTrunkPhoneNumber(ctx context.Context, id string) (*ImportSIPTrunkPhoneNumberResponse, error) {
+	return Get[ImportSIPTrunkPhoneNumberResponse](ctx, c, PhoneNumberEndpoint, id)
 }
 
-// UpdateToolQueryFunction method.
-func (c *APIClient) UpdateToolQueryFunction(id string, requestData ToolQueryFunctionRequest) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("tool/%s", id)
-	return c.SendRequest("PATCH", endpoint, requestData)
+// UpdateSIPTrunkPhoneNumber updates a SIP trunk phone number by ID.
+func (c *APIClient) UpdateSIPTrunkPhoneNumber(ctx context.Context, id string, requestData ImportSIPTrunkPhoneNumberRequest) (*ImportSIPTrunkPhoneNumberResponse, error) {
+	return Update[ImportSIPTrunkPhoneNumberResponse](ctx, c, PhoneNumberEndpoint, id, requestData)
 }
 
-// GetToolQueryFunction retrieves the details of a specific tool by ID.
-func (c *APIClient) GetToolQueryFunction(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("tool/%s", id)
-	return c.SendRequest("GET", endpoint, nil)
+// DeletePhoneNumber deletes a specific phone number by ID.
+func (c *APIClient) DeletePhoneNumber(ctx context.Context, id string) error {
+	return Delete(ctx, c, PhoneNumberEndpoint, id)
 }
 
-// DeleteToolQueryFunction deletes a specific tool by ID.
-func (c *APIClient) DeleteToolQueryFunction(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("tool/%s", id)
-	return c.SendRequest("DELETE", endpoint, nil)
+// CreateTool creates a built-in tool such as transferCall or endCall.
+func (c *APIClient) CreateTool(ctx context.Context, requestData ToolRequest) (*ToolResponse, error) {
+	return Create[ToolResponse](ctx, c, ToolEndpoint, requestData)
 }
 
-// CreateToolFunction method.
-func (c *APIClient) CreateToolFunction(requestData ToolFunctionRequest) ([]byte, int, error) {
-	return c.SendRequest("POST", "tool", requestData)
+// UpdateTool updates a built-in tool by ID. Only the fields set in
+// requestData are changed.
+func (c *APIClient) UpdateTool(ctx context.Context, id string, requestData ToolRequest) (*ToolResponse, error) {
+	return Update[ToolResponse](ctx, c, ToolEndpoint, id, requestData)
 }
 
-// UpdateToolFunction updates an existing tool function by ID.
-func (c *APIClient) UpdateToolFunction(id string, requestData ToolFunctionRequest) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("tool/%s", id)
-	return c.SendRequest("PATCH", endpoint, requestData)
+// GetTool retrieves a built-in tool by ID.
+func (c *APIClient) GetTool(ctx context.Context, id string) (*ToolResponse, error) {
+	return Get[ToolResponse](ctx, c, ToolEndpoint, id)
 }
 
-// GetToolFunction retrieves the details of a specific tool by ID.
-func (c *APIClient) GetToolFunction(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("tool/%s", id)
-	return c.SendRequest("GET", endpoint, nil)
+// DeleteTool deletes a tool of any type by ID.
+func (c *APIClient) DeleteTool(ctx context.Context, id string) error {
+	return Delete(ctx, c, ToolEndpoint, id)
 }
 
-// DeleteToolFunction deletes a specific tool by ID.
-func (c *APIClient) DeleteToolFunction(id string) ([]byte, int, error) {
-	if len(id) == 0 {
-		return []byte{}, 404, nil
-	}
-	endpoint := fmt.Sprintf("tool/%s", id)
-	return c.SendRequest("DELETE", endpoint, nil)
+// CreateToolQueryFunction creates a new query tool.
+func (c *APIClient) CreateToolQueryFunction(ctx context.Context, requestData ToolQueryFunctionRequest) (*ToolQueryFunctionResponse, error) {
+	return Create[ToolQueryFunctionResponse](ctx, c, ToolEndpoint, requestData)
 }
 
-// CreateAssistant creates a new assistant.
-func (c *APIClient) CreateAssistant(requestData CreateAssistantRequest) ([]byte, int, error) {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(requestData); err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to encode request data: %v", err)
-	}
-
-	return c.SendRequest("POST", "assistant", requestData)
+// UpdateToolQueryFunction updates an existing query tool by ID.
+func (c *APIClient) UpdateToolQueryFunction(ctx context.Context, id string, requestData ToolQueryFunctionRequest) (*ToolQueryFunctionResponse, error) {
+	return Update[ToolQueryFunctionResponse](ctx, c, ToolEndpoint, id, requestData)
 }
 
-// UpdateAssistant updates assistant.
-func (c *APIClient) UpdateAssistant(id string, requestData CreateAssistantRequest) ([]byte, int, error) {
-
-	if id == "" {
-		return nil, http.StatusNotFound, fmt.Errorf("ID cannot be empty")
-	}
-
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(requestData); err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to encode request data: %v", err)
-	}
-
-	endpoint := fmt.Sprintf("assistant/%s", id)
-	return c.SendRequest("PATCH", endpoint, requestData)
+// GetToolQueryFunction retrieves the details of a specific query tool by ID.
+func (c *APIClient) GetToolQueryFunction(ctx context.Context, id string) (*ToolQueryFunctionResponse, error) {
+	return Get[ToolQueryFunctionResponse](ctx, c, ToolEndpoint, id)
 }
 
-// GetAssistant retrieves the details of a specific assistant by ID.
-func (c *APIClient) GetAssistant(id string) ([]byte, int, error) {
-	if id == "" {
-		return nil, http.StatusNotFound, fmt.Errorf("ID cannot be empty")
-	}
-
-	endpoint := fmt.Sprintf("assistant/%s", id)
-	return c.SendRequest("GET", endpoint, nil)
+// DeleteToolQueryFunction deletes a specific query tool by ID.
+func (c *APIClient) DeleteToolQueryFunction(ctx context.Context, id string) error {
+	return c.DeleteTool(ctx, id)
 }
 
-// DeleteAssistant deletes an existing assistant by ID.
-func (c *APIClient) DeleteAssistant(id string) ([]byte, int, error) {
-	if id == "" {
-		return nil, http.StatusNotFound, fmt.Errorf("ID cannot be empty")
-	}
+// CreateToolFunction creates a new function tool.
+func (c *APIClient) CreateToolFunction(ctx context.Context, requestData ToolFunctionRequest) (*ToolFunctionResponse, error) {
+	return Create[ToolFunctionResponse](ctx, c, ToolEndpoint, requestData)
+}
 
-	endpoint := fmt.Sprintf("assistant/%s", id)
-	return c.SendRequest("DELETE", endpoint, nil)
+// UpdateToolFunction updates an existing tool function by ID. Only the fields
+// set in requestData are changed.
+func (c *APIClient) UpdateToolFunction(ctx context.Context, id string, requestData UpdateToolFunctionRequest) (*ToolFunctionResponse, error) {
+	return Update[ToolFunctionResponse](ctx, c, ToolEndpoint, id, requestData)
 }
 
-// CreateSIPTrunk creates a new assistant.
-func (c *APIClient) CreateSIPTrunk(requestData ImportSIPTrunkRequest) ([]byte, int, error) {
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(requestData); err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to encode request data: %v", err)
-	}
+// GetToolFunction retrieves the details of a specific tool by ID.
+func (c *APIClient) GetToolFunction(ctx context.Context, id string) (*ToolFunctionResponse, error) {
+	return Get[ToolFunctionResponse](ctx, c, ToolEndpoint, id)
+}
 
-	return c.SendRequest("POST", "credential", requestData)
+// DeleteToolFunction deletes a specific tool by ID.
+func (c *APIClient) DeleteToolFunction(ctx context.Context, id string) error {
+	return c.DeleteTool(ctx, id)
 }
 
-// UpdateSIPTrunk updates assistant.
-func (c *APIClient) UpdateSIPTrunk(id string, requestData ImportSIPTrunkRequest) ([]byte, int, error) {
+// CreateAssistant creates a new assistant.
+func (c *APIClient) CreateAssistant(ctx context.Context, requestData CreateAssistantRequest) (*Assistant, error) {
+	return Create[Assistant](ctx, c, AssistantEndpoint, requestData)
+}
 
-	if id == "" {
-		return nil, http.StatusNotFound, fmt.Errorf("ID cannot be empty")
-	}
+// UpdateAssistant updates an existing assistant by ID.
+func (c *APIClient) UpdateAssistant(ctx context.Context, id string, requestData CreateAssistantRequest) (*Assistant, error) {
+	return Update[Assistant](ctx, c, AssistantEndpoint, id, requestData)
+}
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(requestData); err != nil {
-		return nil, http.StatusInternalServerError, fmt.Errorf("failed to encode request data: %v", err)
-	}
+// GetAssistant retrieves the details of a specific assistant by ID.
+func (c *APIClient) GetAssistant(ctx context.Context, id string) (*Assistant, error) {
+	return Get[Assistant](ctx, c, AssistantEndpoint, id)
+}
 
-	endpoint := fmt.Sprintf("credential/%s", id)
-	return c.SendRequest("PATCH", endpoint, requestData)
+// DeleteAssistant deletes an existing assistant by ID.
+func (c *APIClient) DeleteAssistant(ctx context.Context, id string) error {
+	return Delete(ctx, c, AssistantEndpoint, id)
 }
 
-// GetSIPTrunk retrieves the details of a specific assistant by ID.
-func (c *APIClient) GetSIPTrunk(id string) ([]byte, int, error) {
-	if id == "" {
-		return nil, http.StatusNotFound, fmt.Errorf("ID cannot be empty")
-	}
+// CreateSIPTrunk creates a new SIP trunk credential.
+func (c *APIClient) CreateSIPTrunk(ctx context.Context, requestData ImportSIPTrunkRequest) (*SIPTrunk, error) {
+	return Create[SIPTrunk](ctx, c, CredentialEndpoint, requestData)
+}
 
-	endpoint := fmt.Sprintf("credential/%s", id)
-	return c.SendRequest("GET", endpoint, nil)
+// UpdateSIPTrunk updates an existing SIP trunk credential by ID.
+func (c *APIClient) UpdateSIPTrunk(ctx context.Context, id string, requestData ImportSIPTrunkRequest) (*SIPTrunk, error) {
+	return Update[SIPTrunk](ctx, c, CredentialEndpoint, id, requestData)
 }
 
-// DeleteSIPTrunk deletes an existing assistant by ID.
-func (c *APIClient) DeleteSIPTrunk(id string) ([]byte, int, error) {
-	if id == "" {
-		return nil, http.StatusNotFound, fmt.Errorf("ID cannot be empty")
-	}
+// GetSIPTrunk retrieves the details of a specific SIP trunk credential by ID.
+func (c *APIClient) GetSIPTrunk(ctx context.Context, id string) (*SIPTrunk, error) {
+	return Get[SIPTrunk](ctx, c, CredentialEndpoint, id)
+}
 
-	endpoint := fmt.Sprintf("credential/%s", id)
-	return c.SendRequest("DELETE", endpoint, nil)
+// DeleteSIPTrunk deletes an existing SIP trunk credential by ID.
+func (c *APIClient) DeleteSIPTrunk(ctx context.Context, id string) error {
+	return Delete(ctx, c, CredentialEndpoint, id)
 }